@@ -1,15 +1,19 @@
 package routes
 
 import (
+	"workout_tracker/internal/config"
 	auth "workout_tracker/internal/controllers/auth"
 	exercise "workout_tracker/internal/controllers/exercise"
 	user "workout_tracker/internal/controllers/user"
 	workout "workout_tracker/internal/controllers/workout"
+	"workout_tracker/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 func RegisterRoutes(api *gin.RouterGroup) {
+	idempotent := middleware.Idempotency(config.GetDB(), config.IdempotencyTTL())
+
 	// auth routes
 	api.POST("/register", auth.Register)
 	api.POST("/login", auth.Login)
@@ -21,6 +25,7 @@ func RegisterRoutes(api *gin.RouterGroup) {
 	// user routes
 	api.GET("/users", user.GetMyProfile)
 	api.PATCH("/users/change-password", user.UpdatePassword)
+	api.PATCH("/users/preferences", user.UpdatePreferences)
 
 	// exercise routes
 	api.GET("/exercises", exercise.GetAllExercises)
@@ -28,13 +33,43 @@ func RegisterRoutes(api *gin.RouterGroup) {
 
 	// workout routes
 	api.GET("/workouts", workout.GetMyWorkouts)
-	api.POST("/workouts", workout.CreateWorkout)
+	api.POST("/workouts", idempotent, workout.CreateWorkout)
+	api.POST("/workouts/bulk", idempotent, workout.BulkWorkouts)
+	api.GET("/workouts/trash", workout.GetTrash)
 	api.GET("/workouts/:id", workout.GetWorkoutByID)
 	api.PATCH("/workouts/:id", workout.UpdateWorkout)
 	api.DELETE("/workouts/:id", workout.DeleteWorkout)
+	api.POST("/workouts/:id/restore", workout.RestoreWorkout)
+	api.POST("/workouts/:id/exercises", workout.AddWorkoutExercise)
+	api.PUT("/workouts/:id/exercises/order", workout.ReorderWorkoutExercises)
+	api.PATCH("/workouts/:id/exercises/:entry_id", workout.UpdateWorkoutExercise)
+	api.DELETE("/workouts/:id/exercises/:entry_id", workout.RemoveWorkoutExercise)
+	api.POST("/workouts/:id/groups", workout.AddWorkoutGroup)
+	api.PATCH("/workouts/:id/groups/:group_id", workout.UpdateWorkoutGroup)
+	api.DELETE("/workouts/:id/groups/:group_id", workout.RemoveWorkoutGroup)
 	api.GET("/workouts/schedules", workout.GetMyWorkoutSchedules)
-	api.POST("/workouts/schedules", workout.CreateSchedule)
+	api.POST("/workouts/schedules", idempotent, workout.CreateSchedule)
+	api.POST("/workouts/schedules/bulk", idempotent, workout.BulkSchedules)
 	api.GET("/workouts/schedules/:id", workout.GetScheduleByID)
 	api.GET("/workouts/schedules/status", workout.FilterByStatus)
+	api.GET("/workouts/sessions", workout.GetMySessions)
+	api.POST("/workouts/sessions", idempotent, workout.StartWorkoutSession)
+	api.GET("/workouts/sessions/:id", workout.GetSessionByID)
+	api.POST("/workouts/sessions/:id/sets", workout.LogSessionSet)
+	api.PATCH("/workouts/sessions/:id/sets/:set_id", workout.UpdateSessionSet)
+	api.DELETE("/workouts/sessions/:id/sets/:set_id", workout.DeleteSessionSet)
+	api.POST("/workouts/sessions/:id/finish", workout.FinishWorkoutSession)
 	api.GET("/workouts/reports", workout.GenerateWorkoutReport)
+	api.GET("/records", workout.GetRecords)
+	api.GET("/one-rep-maxes", workout.GetOneRepMaxes)
+	api.GET("/one-rep-maxes/:exercise_id", workout.GetOneRepMax)
+	api.GET("/one-rep-maxes/:exercise_id/history", workout.GetOneRepMaxHistory)
+	api.PUT("/one-rep-maxes/:exercise_id/training-max", workout.SetTrainingMax)
+	api.DELETE("/one-rep-maxes/:exercise_id/training-max", workout.ClearTrainingMax)
+	api.GET("/programs", workout.GetMyPrograms)
+	api.POST("/programs", idempotent, workout.CreateProgram)
+	api.GET("/programs/:id", workout.GetProgramByID)
+	api.PATCH("/programs/:id", workout.UpdateProgram)
+	api.DELETE("/programs/:id", workout.DeleteProgram)
+	api.POST("/programs/:id/enroll", idempotent, workout.EnrollInProgram)
 }
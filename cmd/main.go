@@ -1,18 +1,33 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
 	routes "workout_tracker/api"
 	"workout_tracker/internal/config"
+	health "workout_tracker/internal/controllers/health"
+	"workout_tracker/internal/trash"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/lifecycle"
+	"workout_tracker/pkg/logging"
+	"workout_tracker/pkg/metrics"
 	"workout_tracker/pkg/middleware"
+	"workout_tracker/pkg/tracing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/juju/ratelimit"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	_ "workout_tracker/docs"
 )
@@ -37,23 +52,103 @@ func main() {
 	if env != nil {
 		log.Fatal("Error loading .env file")
 	}
+	slog.SetDefault(logging.New(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT")))
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+	migrateOnStart()
+	metrics.InstrumentDB(config.GetDB())
+	tracing.InstrumentDB(config.GetDB(), config.Dialect())
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	rate, _ := strconv.ParseInt(os.Getenv("RATE"), 10, 64)
 	capacity, _ := strconv.ParseInt(os.Getenv("CAPACITY"), 10, 64)
 	rateLimiter := ratelimit.NewBucketWithRate(float64(rate), capacity)
 
-	app := gin.Default()
-	app.Use(gin.Recovery())
-	app.Use(gin.Logger())
+	app := gin.New()
+	app.Use(middleware.Recovery())
+	app.Use(otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return r.URL.Path != "/healthz" && r.URL.Path != "/readyz" && r.URL.Path != "/metrics"
+	})))
+	app.Use(middleware.RequestID())
+	app.Use(middleware.Logger("/healthz", "/readyz", "/metrics"))
+	app.Use(middleware.Metrics("/metrics"))
+	// Probes and metrics sit outside /api/v1 so they are never rate limited.
+	app.GET("/healthz", health.Liveness)
+	app.GET("/readyz", health.Readiness)
+	app.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	api := app.Group("/api/v1", middleware.NonBlockingRateLimitMiddleware(rateLimiter))
 	{
 		api.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.DocExpansion("none")))
 		routes.RegisterRoutes(api)
 	}
+	app.NoRoute(func(c *gin.Context) {
+		apperror.Abort(c, apperror.NotFound("route_not_found", "Route not found"))
+	})
 	app.GET("/", gin.HandlerFunc(func(c *gin.Context) {
 		c.Redirect(http.StatusMovedPermanently, "/api/v1/swagger/index.html")
 	}))
-	err := app.Run(":" + os.Getenv("PORT"))
-	if err != nil {
+
+	serverConfig := config.LoadServerConfig()
+	server := &http.Server{
+		Addr:              ":" + serverConfig.Port,
+		Handler:           app,
+		ReadTimeout:       serverConfig.ReadTimeout,
+		ReadHeaderTimeout: serverConfig.ReadHeaderTimeout,
+		WriteTimeout:      serverConfig.WriteTimeout,
+		IdleTimeout:       serverConfig.IdleTimeout,
+		MaxHeaderBytes:    serverConfig.MaxHeaderBytes,
+	}
+	serverErr := make(chan error, 1)
+
+	// Hooks stop in reverse order: the HTTP server drains first and the
+	// database closes last. Register new subsystems between the two.
+	lc := lifecycle.New()
+	lc.Append(lifecycle.Hook{
+		Name:   "database",
+		OnStop: func(ctx context.Context) error { return config.CloseDB() },
+	})
+	lc.Append(lifecycle.Hook{Name: "tracing", OnStop: shutdownTracing})
+	purger := trash.NewPurger(config.GetDB(), config.TrashRetention(), config.TrashPurgeInterval())
+	lc.Append(lifecycle.Hook{Name: "trash purge", OnStart: purger.Start, OnStop: purger.Stop})
+	lc.Append(lifecycle.Hook{
+		Name: "http server",
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					serverErr <- err
+				}
+			}()
+			slog.Info("Listening", "addr", server.Addr)
+			return nil
+		},
+		OnStop: server.Shutdown,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := lc.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
+
+	select {
+	case <-ctx.Done():
+		slog.Info("Shutting down")
+	case err := <-serverErr:
+		slog.Error("HTTP server error", "error", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serverConfig.ShutdownTimeout)
+	defer cancel()
+	if err := lc.Stop(shutdownCtx); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"workout_tracker/internal/config"
+	"workout_tracker/internal/migrate"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status | create <name>"
+
+// runMigrate implements the `migrate` subcommand.
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	if args[0] == "create" {
+		if len(args) < 2 {
+			log.Fatal(migrateUsage)
+		}
+		created, err := migrate.Create(migrate.SourceDir, args[1])
+		for _, path := range created {
+			fmt.Printf("created %s\n", path)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	migrator, err := migrate.New(config.GetDB().DB(), config.Dialect())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		ran, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range ran {
+			fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+		}
+		if len(ran) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				log.Fatal(migrateUsage)
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range reverted {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if s.Modified {
+				state += " (modified)"
+			}
+			fmt.Printf("%04d_%-40s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
+
+// migrateOnStart applies pending migrations before the server starts unless
+// MIGRATE_ON_START is set to false.
+func migrateOnStart() {
+	if enabled, err := strconv.ParseBool(os.Getenv("MIGRATE_ON_START")); err == nil && !enabled {
+		return
+	}
+
+	migrator, err := migrate.New(config.GetDB().DB(), config.Dialect())
+	if err != nil {
+		log.Fatal(err)
+	}
+	ran, err := migrator.Up(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	slog.Info("Database migrated and connected successfully", "applied", len(ran))
+}
@@ -50,10 +50,7 @@ const docTemplate = `{
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
@@ -61,7 +58,7 @@ const docTemplate = `{
         },
         "/exercises": {
             "get": {
-                "description": "Get a list of all exercises",
+                "description": "Get a page of the exercise catalog",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,6 +69,46 @@ const docTemplate = `{
                     "Exercise"
                 ],
                 "summary": "Get all exercises",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "name or created_at, prefixed with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only exercises in this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only exercises for this muscle group",
+                        "name": "muscle_group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only exercises measured by reps_load, reps, duration, distance_duration or distance_load",
+                        "name": "measurement",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -82,13 +119,16 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
@@ -129,28 +169,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
@@ -190,27 +227,27 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             }
         },
-        "/register": {
-            "post": {
-                "description": "Register a new user",
+        "/one-rep-maxes": {
+            "get": {
+                "description": "Get the estimated one-rep max and the training max of the authenticated user on every exercise they logged a set of, or set a training max for. One-rep maxes are estimated from the best set of 1 to 12 reps.",
                 "consumes": [
                     "application/json"
                 ],
@@ -218,60 +255,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Workout"
                 ],
-                "summary": "Register a new user",
+                "summary": "Get user one-rep maxes",
                 "parameters": [
                     {
-                        "description": "User",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers_auth.RegisterUser"
-                        }
+                        "type": "string",
+                        "description": "epley, brzycki or lombardi; defaults to the user's formula",
+                        "name": "formula",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_auth.RegisterUser"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers_workout.OneRepMax"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             }
         },
-        "/reset-password": {
-            "post": {
-                "description": "Reset a user's password",
+        "/one-rep-maxes/{exercise_id}": {
+            "get": {
+                "description": "Get the estimated one-rep max and the training max of the authenticated user on an exercise",
                 "consumes": [
                     "application/json"
                 ],
@@ -279,70 +313,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Workout"
                 ],
-                "summary": "Reset user password",
+                "summary": "Get user one-rep max on an exercise",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Reset Token",
-                        "name": "token",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "exercise_id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers_auth.PasswordReset"
-                        }
+                        "type": "string",
+                        "description": "epley, brzycki or lombardi; defaults to the user's formula",
+                        "name": "formula",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/internal_controllers_workout.OneRepMax"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             }
         },
-        "/send": {
-            "post": {
-                "description": "Send a verification email to the user",
+        "/one-rep-maxes/{exercise_id}/history": {
+            "get": {
+                "description": "Get the best one-rep max of the authenticated user estimated from each of their sessions with sets of an exercise, oldest first, to chart its progress",
                 "consumes": [
                     "application/json"
                 ],
@@ -350,61 +381,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Workout"
                 ],
-                "summary": "Send verification mail",
+                "summary": "Get user one-rep max history on an exercise",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User Email",
-                        "name": "email",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "exercise_id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "epley, brzycki or lombardi; defaults to the user's formula",
+                        "name": "formula",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sessions started on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sessions started on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers_workout.OneRepMaxPoint"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             }
         },
-        "/users": {
-            "get": {
-                "description": "Get the profile information of the authenticated user",
+        "/one-rep-maxes/{exercise_id}/training-max": {
+            "put": {
+                "description": "Set the training max of the authenticated user on an exercise, which prescriptions given as a percentage of the one-rep max are then taken of instead of the estimated one-rep max",
                 "consumes": [
                     "application/json"
                 ],
@@ -412,49 +464,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Workout"
+                ],
+                "summary": "Set user training max on an exercise",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "exercise_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Training max",
+                        "name": "training_max",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.TrainingMax"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/workout_tracker_internal_model_user.User"
+                            "$ref": "#/definitions/internal_controllers_workout.OneRepMax"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
-            }
-        },
-        "/users/change-password": {
-            "patch": {
-                "description": "Change the password of the authenticated user",
+            },
+            "delete": {
+                "description": "Clear the training max the authenticated user set on an exercise, going back to the estimated one-rep max",
                 "consumes": [
                     "application/json"
                 ],
@@ -462,63 +539,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Workout"
                 ],
-                "summary": "Change user password",
+                "summary": "Clear user training max on an exercise",
                 "parameters": [
                     {
-                        "description": "Change Password Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers_user.ChangePassword"
-                        }
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "exercise_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/internal_controllers_workout.OneRepMax"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             }
         },
-        "/verify-email": {
+        "/programs": {
             "get": {
-                "description": "Verify a user's email address",
+                "description": "Get a page of the training programs of the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -526,109 +595,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Workout"
                 ],
-                "summary": "Verify user email",
+                "summary": "Get user training programs",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Verification Token",
-                        "name": "token",
-                        "in": "query",
-                        "required": true
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "created_at or name, prefixed with - for descending",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/workout_tracker_internal_model_workout.Program"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/workouts": {
-            "get": {
-                "description": "Get the workout plan of the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Workout"
-                ],
-                "summary": "Get user workout plan",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlan"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Create a new workout plan for the authenticated user",
+                "description": "Create a training program for the authenticated user from weeks, which may scale the loads and sets of their workouts, and days, each naming the workout plan done on that training day of every week. A week may list days of its own, done that week instead. A 12-week program of 4 days with a deload in week 4 has 12 weeks, the fourth with lower percentages, and 4 days; one alternating two sets of plans has the second set as the days of every other week.",
                 "consumes": [
                     "application/json"
                 ],
@@ -638,58 +661,67 @@ const docTemplate = `{
                 "tags": [
                     "Workout"
                 ],
-                "summary": "Create user workout plan",
+                "summary": "Create user training program",
                 "parameters": [
                     {
-                        "description": "Workout",
-                        "name": "workout",
+                        "description": "Program",
+                        "name": "program",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlan"
+                            "$ref": "#/definitions/internal_controllers_workout.Program"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlan"
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.Program"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             }
         },
-        "/workouts/reports": {
+        "/programs/{id}": {
             "get": {
-                "description": "Get the workout reports of the authenticated user",
+                "description": "Get the training program of the authenticated user by id, with its weeks and days. Send the ETag of a previous response in If-None-Match to get 304 Not Modified while the program is unchanged.",
                 "consumes": [
                     "application/json"
                 ],
@@ -699,47 +731,60 @@ const docTemplate = `{
                 "tags": [
                     "Workout"
                 ],
-                "summary": "Get user workout reports",
+                "summary": "Get user training program by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached program",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutReport"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.Program"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the program"
                             }
                         }
                     },
+                    "304": {
+                        "description": "Not Modified"
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
-            }
-        },
-        "/workouts/schedules": {
-            "get": {
-                "description": "Get the workout schedule of the authenticated user",
+            },
+            "delete": {
+                "description": "Delete a training program of the authenticated user. Workouts already scheduled by enrolling in the program are kept. Send the program's ETag in If-Match to only delete it if nobody changed it since.",
                 "consumes": [
                     "application/json"
                 ],
@@ -749,47 +794,70 @@ const docTemplate = `{
                 "tags": [
                     "Workout"
                 ],
-                "summary": "Get user workout schedule",
+                "summary": "Delete user training program by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the program being deleted",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutSchedule"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create a new workout schedule for the authenticated user",
+            "patch": {
+                "description": "Update a training program of the authenticated user with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json. A merge patch replaces weeks and days as a whole. Workouts already scheduled by enrolling in the program are left as they are. Send the program's ETag in If-Match to only apply the patch if nobody changed the program since.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
                 ],
                 "produces": [
                     "application/json"
@@ -797,67 +865,98 @@ const docTemplate = `{
                 "tags": [
                     "Workout"
                 ],
-                "summary": "Create new user workout schedule",
+                "summary": "Update user training program",
                 "parameters": [
                     {
-                        "description": "Workout Schedule",
-                        "name": "schedule",
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the program being patched",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch with the fields to change",
+                        "name": "program",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutSchedule"
+                            "$ref": "#/definitions/internal_controllers_workout.Program"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutSchedule"
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.Program"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the program"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             }
         },
-        "/workouts/schedules/status": {
-            "get": {
-                "description": "Filter the workout schedule of the authenticated user by status",
+        "/programs/{id}/enroll": {
+            "post": {
+                "description": "Start a training program of the authenticated user on start_date by scheduling its workouts. Week n of the program covers the 7 days from start_date plus n-1 weeks, and its days are scheduled in order on the first of those dates that fall on a training day. Training days are those of the request, or the user's preferred training days, and must be at least as many as the days of the busiest week of the program. Each schedule records its program week, whose percentages scale its loads and sets when it is retrieved.",
                 "consumes": [
                     "application/json"
                 ],
@@ -867,65 +966,80 @@ const docTemplate = `{
                 "tags": [
                     "Workout"
                 ],
-                "summary": "Filter user workout schedule by status",
+                "summary": "Enroll in a training program",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Workout Status",
-                        "name": "status",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Enrollment",
+                        "name": "enrollment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.Enrollment"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutSchedule"
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.ProgramEnrollment"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             }
         },
-        "/workouts/schedules/{id}": {
+        "/records": {
             "get": {
-                "description": "Get the workout schedule of the authenticated user by id",
+                "description": "Get a page of the personal records history of the authenticated user. Records are detected when sets are logged in a workout session.",
                 "consumes": [
                     "application/json"
                 ],
@@ -935,65 +1049,87 @@ const docTemplate = `{
                 "tags": [
                     "Workout"
                 ],
-                "summary": "Get user workout schedule by id",
+                "summary": "Get user personal records",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Workout ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 20,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-achieved_at",
+                        "description": "achieved_at, prefixed with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only records on this exercise",
+                        "name": "exercise_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "heaviest_weight, most_reps, estimated_1rm, session_volume or fastest_time",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only records that have not been beaten since",
+                        "name": "current",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutSchedule"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/workout_tracker_internal_model_workout.PersonalRecord"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
             }
         },
-        "/workouts/{id}": {
-            "get": {
-                "description": "Get the workout plan of the authenticated user by id",
+        "/register": {
+            "post": {
+                "description": "Register a new user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1001,65 +1137,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workout"
+                    "Auth"
                 ],
-                "summary": "Get user workout plan by id",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Workout ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_auth.RegisterUser"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlan"
+                            "$ref": "#/definitions/internal_controllers_auth.RegisterUser"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete the workout plan of the authenticated user by id",
+            }
+        },
+        "/reset-password": {
+            "post": {
+                "description": "Reset a user's password",
                 "consumes": [
                     "application/json"
                 ],
@@ -1067,21 +1195,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workout"
+                    "Auth"
                 ],
-                "summary": "Delete user workout plan by id",
+                "summary": "Reset user password",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Workout ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Reset Token",
+                        "name": "token",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "description": "User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_auth.PasswordReset"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1092,43 +1229,33 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update a workout plan for the authenticated user",
+            }
+        },
+        "/send": {
+            "post": {
+                "description": "Send a verification email to the user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1136,36 +1263,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workout"
+                    "Auth"
                 ],
-                "summary": "Update user workout plan",
+                "summary": "Send verification mail",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Workout ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "User Email",
+                        "name": "email",
+                        "in": "query",
                         "required": true
-                    },
-                    {
-                        "description": "Workout",
-                        "name": "workout",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlan"
-                        }
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlan"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1173,17 +1285,101 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
                         }
                     },
                     "404": {
                         "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Get the profile information of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_user.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/change-password": {
+            "patch": {
+                "description": "Change the password of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change user password",
+                "parameters": [
+                    {
+                        "description": "Change Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_user.ChangePassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1191,143 +1387,3944 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/preferences": {
+            "patch": {
+                "description": "Set the unit system, metric (kg, m) or imperial (lb, mi, ft), that the authenticated user's loads and distances are entered and shown in, the formula, epley, brzycki or lombardi, their one-rep maxes are estimated with, and the days of the week, mon to sun, they train on. An empty list of training days clears them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update user preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_user.Preferences"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_user.Preferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-email": {
+            "get": {
+                "description": "Verify a user's email address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Verify user email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification Token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_controllers_auth.LoginUser": {
-            "type": "object",
-            "properties": {
-                "email": {
+        },
+        "/workouts": {
+            "get": {
+                "description": "Get a page of the workout plans of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Get user workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "created_at, name or order, prefixed with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only plans that include this exercise",
+                        "name": "exercise_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new workout plan for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Create user workout plan",
+                "parameters": [
+                    {
+                        "description": "Workout",
+                        "name": "workout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlan"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/bulk": {
+            "post": {
+                "description": "Apply up to 500 operations to the authenticated user's workout plans. In atomic mode (the default) they run in one transaction and either all apply or none do; in partial mode each is applied on its own. Every operation gets a result with its own status and, if it failed, a problem. Responds 200 when every operation applied, 207 when a partial request had failures, and 422 when an atomic request was rolled back.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Create, update and delete workout plans in bulk",
+                "parameters": [
+                    {
+                        "description": "Operations; data is a WorkoutPlan for create and a merge patch of a WorkoutPlanPatch for update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.BulkRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.BulkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/reports": {
+            "get": {
+                "description": "Get a report per workout plan of the authenticated user with the number of exercises, the sets and repetitions they prescribe, and their average weight",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Get user workout reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controllers_workout.WorkoutReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/schedules": {
+            "get": {
+                "description": "Get a page of the workout schedule of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Get user workout schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "scheduled_date",
+                        "description": "scheduled_date, created_at or status, prefixed with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only schedules with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only schedules on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only schedules on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSchedule"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new workout schedule for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Create new user workout schedule",
+                "parameters": [
+                    {
+                        "description": "Workout Schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.WorkoutSchedule"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.WorkoutSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/schedules/bulk": {
+            "post": {
+                "description": "Apply up to 500 operations to the authenticated user's workout schedules, with the same modes and results as POST /workouts/bulk.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Create, update and delete workout schedules in bulk",
+                "parameters": [
+                    {
+                        "description": "Operations; data is a WorkoutSchedule for create and a merge patch of one for update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.BulkRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.BulkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/schedules/status": {
+            "get": {
+                "description": "Get a page of the workout schedule of the authenticated user with the given status. Takes the same parameters as GET /workouts/schedules.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Filter user workout schedule by status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workout Status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "scheduled_date",
+                        "description": "scheduled_date, created_at or status, prefixed with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSchedule"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/schedules/{id}": {
+            "get": {
+                "description": "Get the workout schedule of the authenticated user by id. Prescriptions given as a percentage of the one-rep max come with the weight it resolves to from the user's training max, rounded to 2.5 kg or 5 lb. Schedules of a program week have their loads and sets scaled by the week's intensity and volume percentages. Send the ETag of a previous response in If-None-Match to get 304 Not Modified while the schedule, its plan, the units and the training maxes behind its loads are unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Get user workout schedule by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached schedule",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.WorkoutSchedule"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the schedule and of the body it is shown with"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/sessions": {
+            "get": {
+                "description": "Get a page of the workout sessions of the authenticated user, without their sets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Get user workout sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-started_at",
+                        "description": "started_at, prefixed with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "in_progress or finished",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSession"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Start a workout session for the authenticated user, optionally following one of their plans or opening one of their scheduled workouts. A user can only have one session in progress.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Start a workout session",
+                "parameters": [
+                    {
+                        "description": "Session",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.StartSession"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/sessions/{id}": {
+            "get": {
+                "description": "Get a workout session of the authenticated user with its logged sets and, when it follows a plan, the plan's prescriptions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Get user workout session by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSession"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/sessions/{id}/finish": {
+            "post": {
+                "description": "Finish a workout session of the authenticated user that is in progress. When the session was started from a schedule, the schedule is marked completed at the time the session finished. The progression rules of the plan the session follows then move the loads of the plan for its next scheduled workout, each change being listed with its explanation in the adjustments of the session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Finish a workout session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notes replacing those of the session",
+                        "name": "session",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.FinishSession"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/sessions/{id}/sets": {
+            "post": {
+                "description": "Log a performed set in a workout session of the authenticated user that is in progress. Personal records the set beats are listed in its records, and records for the volume of the session in those of the session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Log a set in a workout session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Set",
+                        "name": "set",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.LoggedSet"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/sessions/{id}/sets/{set_id}": {
+            "delete": {
+                "description": "Delete a set logged in a workout session of the authenticated user that is in progress. The sets after it move up by one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Delete a logged set",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Set ID",
+                        "name": "set_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSession"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Correct a set logged in a workout session of the authenticated user that is in progress, with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Update a logged set",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Set ID",
+                        "name": "set_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch with the fields to change",
+                        "name": "set",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.LoggedSet"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/trash": {
+            "get": {
+                "description": "Get a page of the authenticated user's deleted workout plans. They can be restored until they are purged, TRASH_RETENTION_DAYS after deletion.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Get deleted workout plans",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deleted_at",
+                        "description": "deleted_at or name, prefixed with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{id}": {
+            "get": {
+                "description": "Get the workout plan of the authenticated user by id, with the details of each of its exercises. Send the ETag of a previous response in If-None-Match to get 304 Not Modified while the plan and the units it is shown in are unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Get user workout plan by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached plan",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the plan and the units it is shown in"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move the workout plan of the authenticated user, and its schedules, to the trash. Send the plan's ETag in If-Match to only delete it if nobody changed it since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Delete user workout plan by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the plan being deleted",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update a workout plan for the authenticated user with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json. Only the fields of WorkoutPlanPatch can be changed; exercises are changed through /workouts/{id}/exercises. Send the plan's ETag in If-Match to only apply the patch if nobody changed the plan since.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Update user workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the plan being patched",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch with the fields to change",
+                        "name": "workout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlanPatch"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the plan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{id}/exercises": {
+            "post": {
+                "description": "Add an exercise with its targets to a workout plan of the authenticated user. The exercises at and after position move down by one; position cannot fall between the exercises of a group. Send the plan's ETag in If-Match to only add it if nobody changed the plan since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Add an exercise to a workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the plan",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Exercise",
+                        "name": "exercise",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.NewWorkoutPlanExercise"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the plan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{id}/exercises/order": {
+            "put": {
+                "description": "Put the exercises of a workout plan of the authenticated user in a new order. ids must list the id of every exercise of the plan exactly once, keeping the exercises of each group together. Send the plan's ETag in If-Match to only reorder them if nobody changed the plan since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Reorder the exercises of a workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the plan",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Exercise ids in their new order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlanExerciseOrder"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the plan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{id}/exercises/{entry_id}": {
+            "delete": {
+                "description": "Remove an exercise from a workout plan of the authenticated user. The exercises after it move up by one; the last exercise of a plan cannot be removed. A group left with too few exercises for its type is ungrouped. Send the plan's ETag in If-Match to only remove it if nobody changed the plan since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Remove an exercise from a workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the exercise in the plan",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the plan",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the plan"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change the targets of an exercise of a workout plan of the authenticated user, including its set prescriptions, which are replaced as a whole, with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json. The sets, repetitions and weight of an exercise with prescriptions are worked out from them, so a patch that changes them together with prescriptions fails with 422. Send the plan's ETag in If-Match to only apply the patch if nobody changed the plan since.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Update an exercise of a workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the exercise in the plan",
+                        "name": "entry_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the plan",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch with the fields to change",
+                        "name": "exercise",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlanExercise"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the plan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{id}/groups": {
+            "post": {
+                "description": "Make a superset, circuit or giant set of adjacent exercises of a workout plan of the authenticated user. A superset has exactly 2 exercises, a giant set at least 3 and a circuit at least 2, none of which may already be in a group. Send the plan's ETag in If-Match to only group them if nobody changed the plan since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Group exercises of a workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the plan",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Group",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlanGroup"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the plan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{id}/groups/{group_id}": {
+            "delete": {
+                "description": "Ungroup the exercises of a group of a workout plan of the authenticated user. The exercises stay in the plan. Send the plan's ETag in If-Match to only ungroup them if nobody changed the plan since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Remove an exercise group from a workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the plan",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the plan"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change the type, rounds, rest or exercises of a group of a workout plan of the authenticated user with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json. Send the plan's ETag in If-Match to only apply the patch if nobody changed the plan since.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Update an exercise group of a workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the plan",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch with the fields to change",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers_workout.WorkoutPlanGroup"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the plan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{id}/restore": {
+            "post": {
+                "description": "Restore a workout plan of the authenticated user from the trash, together with the schedules that were deleted with it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workout"
+                ],
+                "summary": "Restore a deleted workout plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Units of loads and distances, metric or imperial; defaults to the user's preferred units",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the plan"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "internal_controllers_auth.LoginUser": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers_auth.PasswordReset": {
+            "type": "object",
+            "required": [
+                "confirm_password",
+                "password"
+            ],
+            "properties": {
+                "confirm_password": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "internal_controllers_auth.RegisterUser": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "internal_controllers_user.ChangePassword": {
+            "type": "object",
+            "required": [
+                "confirm_password",
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "confirm_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers_user.Preferences": {
+            "type": "object",
+            "properties": {
+                "one_rm_formula": {
+                    "type": "string",
+                    "enum": [
+                        "epley",
+                        "brzycki",
+                        "lombardi"
+                    ],
+                    "example": "epley"
+                },
+                "preferred_units": {
+                    "type": "string",
+                    "enum": [
+                        "metric",
+                        "imperial"
+                    ],
+                    "example": "metric"
+                },
+                "training_days": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mon",
+                        "wed",
+                        "fri"
+                    ]
+                }
+            }
+        },
+        "internal_controllers_workout.BulkOperation": {
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "if_match": {
+                    "type": "string"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ],
+                    "example": "create"
+                }
+            }
+        },
+        "internal_controllers_workout.BulkRequest": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "partial"
+                    ],
+                    "example": "atomic"
+                },
+                "operations": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_workout.BulkOperation"
+                    }
+                }
+            }
+        },
+        "internal_controllers_workout.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_workout.BulkResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers_workout.BulkResult": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/workout_tracker_pkg_apperror.Error"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers_workout.Enrollment": {
+            "type": "object",
+            "required": [
+                "start_date"
+            ],
+            "properties": {
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-01-05"
+                },
+                "training_days": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mon",
+                        "wed",
+                        "fri"
+                    ]
+                }
+            }
+        },
+        "internal_controllers_workout.FinishSession": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "internal_controllers_workout.LoggedSet": {
+            "type": "object",
+            "required": [
+                "exercise_id"
+            ],
+            "properties": {
+                "calories": {
+                    "type": "integer",
+                    "maximum": 20000,
+                    "minimum": 0
+                },
+                "distance": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "duration_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
+                },
+                "elevation": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "heart_rate": {
+                    "type": "integer",
+                    "maximum": 250,
+                    "minimum": 30
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "pace": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "reps": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "rpe": {
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "workout_plan_exercise_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers_workout.NewWorkoutPlanExercise": {
+            "type": "object",
+            "required": [
+                "exercise_id"
+            ],
+            "properties": {
+                "calories": {
+                    "type": "integer",
+                    "maximum": 20000,
+                    "minimum": 0
+                },
+                "distance": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "duration_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
+                },
+                "elevation": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "heart_rate": {
+                    "type": "integer",
+                    "maximum": 250,
+                    "minimum": 30
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "pace": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "prescriptions": {
+                    "type": "array",
+                    "maxItems": 30,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_workout.SetPrescription"
+                    }
+                },
+                "progression": {
+                    "$ref": "#/definitions/internal_controllers_workout.Progression"
+                },
+                "repetitions": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "rest_seconds": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 0
+                },
+                "sets": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_controllers_workout.OneRepMax": {
+            "type": "object",
+            "properties": {
+                "estimated_1rm": {
+                    "type": "number"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "formula": {
+                    "type": "string"
+                },
+                "training_max": {
+                    "type": "number"
+                },
+                "training_max_overridden": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_controllers_workout.OneRepMaxPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "estimated_1rm": {
+                    "type": "number"
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "workout_session_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers_workout.Program": {
+            "type": "object",
+            "required": [
+                "days",
+                "name",
+                "weeks"
+            ],
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_workout.ProgramDay"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "weeks": {
+                    "type": "array",
+                    "maxItems": 52,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_workout.ProgramWeek"
+                    }
+                }
+            }
+        },
+        "internal_controllers_workout.ProgramDay": {
+            "type": "object",
+            "required": [
+                "workout_plan_id"
+            ],
+            "properties": {
+                "workout_plan_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers_workout.ProgramWeek": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_workout.ProgramDay"
+                    }
+                },
+                "intensity_percent": {
+                    "type": "number",
+                    "maximum": 200,
+                    "example": 100
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "volume_percent": {
+                    "type": "number",
+                    "maximum": 200,
+                    "example": 100
+                }
+            }
+        },
+        "internal_controllers_workout.Progression": {
+            "type": "object",
+            "required": [
+                "increment",
+                "type"
+            ],
+            "properties": {
+                "deload_after": {
+                    "type": "integer",
+                    "maximum": 20,
+                    "minimum": 0
+                },
+                "deload_percent": {
+                    "type": "number",
+                    "maximum": 50,
+                    "minimum": 0
+                },
+                "increment": {
+                    "type": "number"
+                },
+                "target_rpe": {
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "linear",
+                        "double",
+                        "rpe"
+                    ],
+                    "example": "linear"
+                }
+            }
+        },
+        "internal_controllers_workout.SetPrescription": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "calories": {
+                    "type": "integer",
+                    "maximum": 20000,
+                    "minimum": 0
+                },
+                "distance": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "duration_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
+                },
+                "elevation": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "heart_rate": {
+                    "type": "integer",
+                    "maximum": 250,
+                    "minimum": 30
+                },
+                "max_reps": {
+                    "type": "integer",
+                    "maximum": 1000
+                },
+                "pace": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "percent_1rm": {
+                    "type": "number",
+                    "maximum": 150
+                },
+                "reps": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "rest_seconds": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 0
+                },
+                "rir": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "rpe": {
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "warmup",
+                        "working",
+                        "drop",
+                        "failure",
+                        "amrap"
+                    ],
+                    "example": "working"
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_controllers_workout.StartSession": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "workout_plan_id": {
+                    "type": "integer"
+                },
+                "workout_schedule_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers_workout.TrainingMax": {
+            "type": "object",
+            "required": [
+                "training_max"
+            ],
+            "properties": {
+                "training_max": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_controllers_workout.WorkoutPlan": {
+            "type": "object",
+            "required": [
+                "exercises",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "exercises": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_workout.WorkoutPlanExercise"
+                    }
+                },
+                "groups": {
+                    "type": "array",
+                    "maxItems": 25,
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_workout.WorkoutPlanGroup"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "order": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_controllers_workout.WorkoutPlanExercise": {
+            "type": "object",
+            "required": [
+                "exercise_id"
+            ],
+            "properties": {
+                "calories": {
+                    "type": "integer",
+                    "maximum": 20000,
+                    "minimum": 0
+                },
+                "distance": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "duration_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
+                },
+                "elevation": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "heart_rate": {
+                    "type": "integer",
+                    "maximum": 250,
+                    "minimum": 30
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "pace": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "prescriptions": {
+                    "type": "array",
+                    "maxItems": 30,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers_workout.SetPrescription"
+                    }
+                },
+                "progression": {
+                    "$ref": "#/definitions/internal_controllers_workout.Progression"
+                },
+                "repetitions": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "rest_seconds": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 0
+                },
+                "sets": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_controllers_workout.WorkoutPlanExerciseOrder": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "internal_controllers_workout.WorkoutPlanGroup": {
+            "type": "object",
+            "required": [
+                "exercises",
+                "type"
+            ],
+            "properties": {
+                "exercises": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 2,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "rest_seconds": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 0
+                },
+                "rounds": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 0
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "superset",
+                        "circuit",
+                        "giant_set"
+                    ],
+                    "example": "superset"
+                }
+            }
+        },
+        "internal_controllers_workout.WorkoutPlanPatch": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "order": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_controllers_workout.WorkoutReport": {
+            "type": "object",
+            "properties": {
+                "average_weight": {
+                    "type": "number"
+                },
+                "total_exercises": {
+                    "type": "integer"
+                },
+                "total_reps": {
+                    "type": "integer"
+                },
+                "total_sets": {
+                    "type": "integer"
+                },
+                "workout_name": {
+                    "type": "string"
+                },
+                "workout_plan_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers_workout.WorkoutSchedule": {
+            "type": "object",
+            "required": [
+                "scheduled_date",
+                "workout_plan_id"
+            ],
+            "properties": {
+                "completed_date": {
+                    "type": "string"
+                },
+                "scheduled_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "completed",
+                        "skipped",
+                        "cancelled"
+                    ]
+                },
+                "workout_plan_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "workout_tracker_internal_model_exercise.Exercise": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "measurement": {
+                    "type": "string"
+                },
+                "muscle_group": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "workout_tracker_internal_model_exercise.ExerciseCategory": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "workout_tracker_internal_model_user.User": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_verified": {
+                    "type": "boolean"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "one_rm_formula": {
+                    "description": "OneRMFormula is the formula the user's one-rep maxes are estimated with.",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "preferred_units": {
+                    "description": "PreferredUnits is the unit system, metric or imperial, that the user's\nloads and distances are entered and shown in.",
+                    "type": "string"
+                },
+                "reset_exp_time": {
+                    "type": "integer"
+                },
+                "reset_token": {
+                    "type": "string"
+                },
+                "training_days": {
+                    "description": "TrainingDays are the days of the week the user trains on, which\nprograms are scheduled on unless an enrollment names others.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verify_exp_time": {
+                    "type": "integer"
+                },
+                "verify_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "workout_tracker_internal_model_workout.PersonalRecord": {
+            "type": "object",
+            "properties": {
+                "achieved_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "estimated_1rm": {
+                    "type": "number"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "volume": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "workout_session_id": {
+                    "type": "integer"
+                },
+                "workout_session_set_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "workout_tracker_internal_model_workout.Program": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "days": {
+                    "description": "Days are the days of every week. Those of single weeks have a\nProgramWeekId and are only listed with their week.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.ProgramDay"
+                    }
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "weeks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.ProgramWeek"
+                    }
+                }
+            }
+        },
+        "workout_tracker_internal_model_workout.ProgramDay": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "program_week_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "workout_plan_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "workout_tracker_internal_model_workout.ProgramEnrollment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSchedule"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "training_days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "workout_tracker_internal_model_workout.ProgramWeek": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.ProgramDay"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "intensity_percent": {
+                    "type": "number"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "volume_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "workout_tracker_internal_model_workout.ProgressionAdjustment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "previous_weight": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "workout_plan_exercise_id": {
+                    "type": "integer"
+                },
+                "workout_schedule_id": {
+                    "type": "integer"
+                },
+                "workout_session_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "workout_tracker_internal_model_workout.WorkoutPlan": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exercises": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlanExercise"
+                    }
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlanGroup"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "password": {
+                "order": {
+                    "type": "integer"
+                },
+                "updatedAt": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_controllers_auth.PasswordReset": {
+        "workout_tracker_internal_model_workout.WorkoutPlanExercise": {
             "type": "object",
             "properties": {
-                "confirm_password": {
+                "calories": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "password": {
+                "distance": {
+                    "type": "number"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "elevation": {
+                    "type": "number"
+                },
+                "exercise": {
+                    "$ref": "#/definitions/workout_tracker_internal_model_exercise.Exercise"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "heart_rate": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "pace": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "prescriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlanSet"
+                    }
+                },
+                "progression": {
+                    "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlanProgression"
+                },
+                "repetitions": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "workout_plan_id": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_controllers_auth.RegisterUser": {
+        "workout_tracker_internal_model_workout.WorkoutPlanGroup": {
             "type": "object",
             "properties": {
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "first_name": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "last_name": {
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "rounds": {
+                    "type": "integer"
+                },
+                "type": {
                     "type": "string"
                 },
-                "password": {
+                "updated_at": {
                     "type": "string"
+                },
+                "workout_plan_id": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_controllers_user.ChangePassword": {
+        "workout_tracker_internal_model_workout.WorkoutPlanProgression": {
             "type": "object",
-            "required": [
-                "confirm_password",
-                "new_password",
-                "old_password"
-            ],
             "properties": {
-                "confirm_password": {
+                "created_at": {
                     "type": "string"
                 },
-                "new_password": {
+                "deload_after": {
+                    "type": "integer"
+                },
+                "deload_percent": {
+                    "type": "number"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "increment": {
+                    "type": "number"
+                },
+                "target_rpe": {
+                    "type": "number"
+                },
+                "type": {
                     "type": "string"
                 },
-                "old_password": {
+                "updated_at": {
                     "type": "string"
+                },
+                "workout_plan_exercise_id": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_controllers_workout.WorkoutPlan": {
+        "workout_tracker_internal_model_workout.WorkoutPlanSet": {
             "type": "object",
             "properties": {
-                "description": {
+                "calories": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "exercise_id": {
+                "distance": {
+                    "type": "number"
+                },
+                "duration_seconds": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "elevation": {
+                    "type": "number"
                 },
-                "order": {
+                "heart_rate": {
                     "type": "integer"
                 },
-                "repetitions": {
+                "id": {
                     "type": "integer"
                 },
-                "sets": {
+                "max_reps": {
                     "type": "integer"
                 },
-                "weight": {
-                    "type": "number"
-                }
-            }
-        },
-        "internal_controllers_workout.WorkoutReport": {
-            "type": "object",
-            "properties": {
-                "average_weight": {
+                "pace": {
+                    "type": "integer"
+                },
+                "percent_1rm": {
                     "type": "number"
                 },
-                "total_reps": {
+                "position": {
                     "type": "integer"
                 },
-                "total_workouts": {
+                "reps": {
                     "type": "integer"
                 },
-                "workout_name": {
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "rir": {
+                    "type": "integer"
+                },
+                "rpe": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "workout_plan_exercise_id": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_controllers_workout.WorkoutSchedule": {
+        "workout_tracker_internal_model_workout.WorkoutSchedule": {
             "type": "object",
             "properties": {
                 "completed_date": {
                     "type": "string"
                 },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "intensity_percent": {
+                    "type": "number"
+                },
+                "program_enrollment_id": {
+                    "type": "integer"
+                },
+                "program_week": {
+                    "type": "integer"
+                },
                 "scheduled_date": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
                 },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "volume_percent": {
+                    "type": "number"
+                },
                 "workout_plan_id": {
                     "type": "integer"
                 }
             }
         },
-        "workout_tracker_internal_model_exercise.Exercise": {
+        "workout_tracker_internal_model_workout.WorkoutSession": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "integer"
+                "adjustments": {
+                    "description": "Adjustments are the changes finishing the session made to the loads of\nits plan.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.ProgressionAdjustment"
+                    }
                 },
                 "createdAt": {
                     "type": "string"
@@ -1335,83 +5332,159 @@ const docTemplate = `{
                 "deletedAt": {
                     "type": "string"
                 },
-                "description": {
+                "finished_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "muscle_group": {
+                "notes": {
                     "type": "string"
                 },
-                "name": {
+                "records": {
+                    "description": "Records are the session-wide personal records set in the session; those\nbeaten by a single set are listed on the set.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.PersonalRecord"
+                    }
+                },
+                "sets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutSessionSet"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "workout_plan": {
+                    "$ref": "#/definitions/workout_tracker_internal_model_workout.WorkoutPlan"
+                },
+                "workout_plan_id": {
+                    "type": "integer"
+                },
+                "workout_schedule_id": {
+                    "type": "integer"
                 }
             }
         },
-        "workout_tracker_internal_model_exercise.ExerciseCategory": {
+        "workout_tracker_internal_model_workout.WorkoutSessionSet": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
+                "calories": {
+                    "type": "integer"
                 },
-                "deletedAt": {
+                "created_at": {
                     "type": "string"
                 },
+                "distance": {
+                    "type": "number"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "elevation": {
+                    "type": "number"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "heart_rate": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "notes": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "pace": {
+                    "type": "integer"
+                },
+                "performed_at": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "records": {
+                    "description": "Records are the personal records the set beat.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_internal_model_workout.PersonalRecord"
+                    }
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "rpe": {
+                    "type": "number"
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "workout_plan_exercise_id": {
+                    "type": "integer"
+                },
+                "workout_session_id": {
+                    "type": "integer"
                 }
             }
         },
-        "workout_tracker_internal_model_user.User": {
+        "workout_tracker_pkg_apperror.Error": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "code": {
                     "type": "string"
                 },
-                "deletedAt": {
+                "detail": {
                     "type": "string"
                 },
-                "email": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workout_tracker_pkg_apperror.FieldError"
+                    }
+                },
+                "instance": {
                     "type": "string"
                 },
-                "first_name": {
+                "request_id": {
                     "type": "string"
                 },
-                "id": {
+                "status": {
                     "type": "integer"
                 },
-                "is_verified": {
-                    "type": "boolean"
-                },
-                "last_name": {
+                "title": {
                     "type": "string"
                 },
-                "password": {
+                "type": {
                     "type": "string"
-                },
-                "reset_exp_time": {
-                    "type": "integer"
-                },
-                "reset_token": {
+                }
+            }
+        },
+        "workout_tracker_pkg_apperror.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "field": {
                     "type": "string"
                 },
-                "verify_exp_time": {
-                    "type": "integer"
-                },
-                "verify_token": {
+                "message": {
                     "type": "string"
                 }
             }
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ServerConfig holds the HTTP server settings read from the environment.
+type ServerConfig struct {
+	Port              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	ShutdownTimeout   time.Duration
+}
+
+func LoadServerConfig() ServerConfig {
+	return ServerConfig{
+		Port:              os.Getenv("PORT"),
+		ReadTimeout:       durationEnv("SERVER_READ_TIMEOUT", 15*time.Second),
+		ReadHeaderTimeout: durationEnv("SERVER_READ_HEADER_TIMEOUT", 5*time.Second),
+		WriteTimeout:      durationEnv("SERVER_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:       durationEnv("SERVER_IDLE_TIMEOUT", 120*time.Second),
+		MaxHeaderBytes:    intEnv("SERVER_MAX_HEADER_BYTES", 1<<20),
+		ShutdownTimeout:   durationEnv("SHUTDOWN_TIMEOUT", 20*time.Second),
+	}
+}
+
+// IdempotencyTTL is how long responses to requests made with an
+// Idempotency-Key are kept for replay.
+func IdempotencyTTL() time.Duration {
+	return durationEnv("IDEMPOTENCY_TTL", 24*time.Hour)
+}
+
+// TrashRetention is how long deleted workout plans and schedules stay in the
+// trash before they are purged. Zero or less keeps them forever.
+func TrashRetention() time.Duration {
+	return time.Duration(intEnv("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour
+}
+
+// TrashPurgeInterval is how often the trash is checked for expired rows.
+func TrashPurgeInterval() time.Duration {
+	return durationEnv("TRASH_PURGE_INTERVAL", time.Hour)
+}
+
+// durationEnv parses a Go duration such as "30s", falling back to def when
+// the variable is unset or invalid.
+func durationEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Warn("Invalid duration, using default", "key", key, "value", value, "default", def)
+		return def
+	}
+	return d
+}
+
+func intEnv(key string, def int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		slog.Warn("Invalid integer, using default", "key", key, "value", value, "default", def)
+		return def
+	}
+	return n
+}
@@ -2,12 +2,13 @@ package controllers
 
 import (
 	"fmt"
-	"log"
 	"net/http"
 	"os"
 	"time"
 	"workout_tracker/internal/config"
 	model "workout_tracker/internal/model/user"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
 	"workout_tracker/pkg/utils"
 
 	"github.com/alexedwards/argon2id"
@@ -16,18 +17,24 @@ import (
 )
 
 type RegisterUser struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
-	Password  string `json:"password"`
+	FirstName string `json:"first_name" binding:"required,max=255"`
+	LastName  string `json:"last_name" binding:"required,max=255"`
+	Email     string `json:"email" binding:"required,email,max=255"`
+	Password  string `json:"password" binding:"required,min=8,max=72"`
 }
 type LoginUser struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
 }
 type PasswordReset struct {
-	Password        string `json:"password"`
-	ConfirmPassword string `json:"confirm_password"`
+	Password        string `json:"password" binding:"required,min=8,max=72"`
+	ConfirmPassword string `json:"confirm_password" binding:"required,eqfield=Password"`
+}
+type EmailQuery struct {
+	Email string `form:"email" binding:"required,email"`
+}
+type TokenQuery struct {
+	Token string `form:"token" binding:"required"`
 }
 
 // @Tags Auth
@@ -37,28 +44,26 @@ type PasswordReset struct {
 // @Produce json
 // @Param user body RegisterUser true "User"
 // @Success 201 {object} RegisterUser
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /register [post]
 func Register(c *gin.Context) {
-	var reqBody model.User
-	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	if reqBody.FirstName == "" || reqBody.LastName == "" || reqBody.Email == "" || reqBody.Password == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "All fields are required"})
+	var input RegisterUser
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-	if !config.GetDB().Where("email = ?", reqBody.Email).First(&model.User{}).RecordNotFound() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Email already exists"})
+	reqBody := model.User{FirstName: input.FirstName, LastName: input.LastName, Email: input.Email, Password: input.Password}
+	if !config.GetDBContext(c.Request.Context()).Where("email = ?", reqBody.Email).First(&model.User{}).RecordNotFound() {
+		apperror.Abort(c, apperror.Conflict("email_taken", "Email already exists"))
 		return
 	}
 
 	hash, err := argon2id.CreateHash(reqBody.Password, argon2id.DefaultParams)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		apperror.Abort(c, apperror.Internal("Failed to hash password", err))
 		return
 	}
 
@@ -66,17 +71,17 @@ func Register(c *gin.Context) {
 	token, exp := utils.GenerateCodeAndTime()
 	reqBody.VerifyToken = token
 	reqBody.VerifyExpTime = exp
-	res := config.GetDB().Create(&reqBody)
+	res := config.GetDBContext(c.Request.Context()).Create(&reqBody)
 	if res.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+		apperror.Abort(c, apperror.Database(res.Error, "Failed to create user"))
 		return
 	}
 
 	subject := "Please verify your email"
 	message := fmt.Sprintf("Subject: %s\n\nHi %s,\n\nPlease verify your email by clicking on the following link:\n\n- %s/verify-email?token=%s\n\nThank you!\n\nWarm regards,\n\nKinetic Core Team", subject, reqBody.FirstName, os.Getenv("APP_URL"), reqBody.VerifyToken)
-	err = utils.SendEmail(reqBody.Email, reqBody.FirstName, subject, message)
+	err = utils.SendEmail(c.Request.Context(), reqBody.Email, reqBody.FirstName, subject, message)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send verification email"})
+		apperror.Abort(c, apperror.Internal("Failed to send verification email", err))
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{"message": "Verification mail sent, check your junk or promotion folder!"})
@@ -89,41 +94,43 @@ func Register(c *gin.Context) {
 // @Produce json
 // @Param email query string true "User Email"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /send [post]
 func SendVerificationEmail(c *gin.Context) {
-	email := c.Query("email")
-	if email == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Email is required"})
+	var query EmailQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
+	email := query.Email
 
 	var user model.User
-	getUser := config.GetDB().Where("email = ?", email).First(&user)
+	getUser := config.GetDBContext(c.Request.Context()).Where("email = ?", email).First(&user)
 	if getUser.Error != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		apperror.Abort(c, apperror.Lookup(getUser.Error, "user_not_found", "User not found"))
 		return
 	}
 	if user.IsVerified {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Email already verified"})
+		apperror.Abort(c, apperror.BadRequest("email_already_verified", "Email already verified"))
 		return
 	}
 
 	token, exp := utils.GenerateCodeAndTime()
 	user.VerifyToken = token
 	user.VerifyExpTime = exp
-	if err := config.GetDB().Save(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save verification details to database"})
+	if err := config.GetDBContext(c.Request.Context()).Save(&user).Error; err != nil {
+		apperror.Abort(c, apperror.Internal("Failed to save verification details to database", err))
 		return
 	}
 
 	subject := "Please verify your email"
 	message := fmt.Sprintf("Subject: %s\n\nHi %s,\n\nPlease verify your email by clicking on the following link:\n\n- %s/verify-email?token=%s\n\nThank you!\n\nWarm regards,\n\nKinetic Core Team", subject, user.FirstName, os.Getenv("APP_URL"), user.VerifyToken)
-	err := utils.SendEmail(user.Email, user.FirstName, subject, message)
+	err := utils.SendEmail(c.Request.Context(), user.Email, user.FirstName, subject, message)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send verification email"})
+		apperror.Abort(c, apperror.Internal("Failed to send verification email", err))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Mail sent, check your junk or promotion folder!"})
@@ -136,37 +143,40 @@ func SendVerificationEmail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /verify-email [get]
 func VerifyEmail(c *gin.Context) {
-	token := c.Query("token")
-	if token == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Token is required"})
+	var query TokenQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
+		return
 	}
+	token := query.Token
 
 	var user model.User
-	if err := config.GetDB().Where("verify_token = ?", token).First(&user).Error; err != nil {
+	if err := config.GetDBContext(c.Request.Context()).Where("verify_token = ?", token).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			apperror.Abort(c, apperror.New(http.StatusUnauthorized, "invalid_token", "Invalid token"))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		apperror.Abort(c, apperror.Internal("Internal server error", err))
 		return
 	}
 
 	convertedExpTime := time.Unix(user.VerifyExpTime, 0)
 	if convertedExpTime.Before(time.Now()) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has expired"})
+		apperror.Abort(c, apperror.New(http.StatusUnauthorized, "token_expired", "Token has expired"))
 		return
 	}
 
 	user.IsVerified = true
 	user.VerifyToken = ""
 	user.VerifyExpTime = 0
-	if err := config.GetDB().Save(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify email"})
+	if err := config.GetDBContext(c.Request.Context()).Save(&user).Error; err != nil {
+		apperror.Abort(c, apperror.Internal("Failed to verify email", err))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
@@ -179,50 +189,45 @@ func VerifyEmail(c *gin.Context) {
 // @Produce json
 // @Param user body LoginUser true "Auth"
 // @Success 200 {object} LoginUser
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /login [post]
 func Login(c *gin.Context) {
-	var reqBody model.User
-	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	if reqBody.Email == "" || reqBody.Password == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "All fields are required"})
+	var reqBody LoginUser
+	if err := validation.BindJSON(c, &reqBody); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 
 	var user model.User
-	verifyEmail := config.GetDB().Where("email = ?", reqBody.Email).First(&user)
+	verifyEmail := config.GetDBContext(c.Request.Context()).Where("email = ?", reqBody.Email).First(&user)
 	if verifyEmail.Error != nil {
 		if verifyEmail.Error == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+			apperror.Abort(c, apperror.New(http.StatusUnauthorized, "invalid_credentials", "Invalid credentials"))
 			return
 		}
-		log.Printf("Database error during authentication: %v", verifyEmail.Error)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		apperror.Abort(c, apperror.Internal("Internal server error", verifyEmail.Error))
 		return
 	}
 
 	match, err := argon2id.ComparePasswordAndHash(reqBody.Password, user.Password)
 	if err != nil {
-		log.Printf("Error during password comparison: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		apperror.Abort(c, apperror.Internal("Internal server error", err))
 		return
 	}
 	if !match {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		apperror.Abort(c, apperror.New(http.StatusUnauthorized, "invalid_credentials", "Invalid credentials"))
 		return
 	}
 	if !user.IsVerified {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not verified"})
+		apperror.Abort(c, apperror.New(http.StatusUnauthorized, "email_not_verified", "Email not verified"))
 		return
 	}
 
 	token, err := utils.SignJWTToken(int64(user.ID), user.Email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		apperror.Abort(c, apperror.Internal("Failed to generate token", err))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Login successful", "token": token})
@@ -235,41 +240,43 @@ func Login(c *gin.Context) {
 // @Produce json
 // @Param email query string true "User Email"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /forgot-password [post]
 func SendForgotPasswordEmail(c *gin.Context) {
-	email := c.Query("email")
-	if email == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Email is required"})
+	var query EmailQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
+	email := query.Email
 
 	var user model.User
-	getUser := config.GetDB().Where("email = ?", email).First(&user)
+	getUser := config.GetDBContext(c.Request.Context()).Where("email = ?", email).First(&user)
 	if getUser.Error != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		apperror.Abort(c, apperror.Lookup(getUser.Error, "user_not_found", "User not found"))
 		return
 	}
 	if !user.IsVerified {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Email not verified"})
+		apperror.Abort(c, apperror.BadRequest("email_not_verified", "Email not verified"))
 		return
 	}
 
 	token, exp := utils.GenerateCodeAndTime()
 	user.ResetToken = token
 	user.ResetExpTime = exp
-	if err := config.GetDB().Save(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save reset details to database."})
+	if err := config.GetDBContext(c.Request.Context()).Save(&user).Error; err != nil {
+		apperror.Abort(c, apperror.Internal("Failed to save reset details to database.", err))
 		return
 	}
 
 	subject := "Reset your password"
 	message := fmt.Sprintf("Subject: %s\n\nHi %s,\n\nPlease reset your password by clicking on the following link:\n\n- %s/reset-password?token=%s\n\nThank you!\n\nWarm regards,\n\nKinetic Core Team", subject, user.FirstName, os.Getenv("APP_URL"), user.ResetToken)
-	err := utils.SendEmail(user.Email, user.FirstName, subject, message)
+	err := utils.SendEmail(c.Request.Context(), user.Email, user.FirstName, subject, message)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send forgot password email"})
+		apperror.Abort(c, apperror.Internal("Failed to send forgot password email", err))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Mail sent, check your junk or promotion folder!"})
@@ -283,58 +290,52 @@ func SendForgotPasswordEmail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /reset-password [post]
 func ResetPassword(c *gin.Context) {
-	token := c.Query("token")
-	if token == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Token is required"})
+	var query TokenQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
+	token := query.Token
 
 	var reqBody PasswordReset
-	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	if reqBody.Password == "" || reqBody.ConfirmPassword == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "All fields are required"})
-		return
-	}
-	if reqBody.Password != reqBody.ConfirmPassword {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Passwords do not match"})
+	if err := validation.BindJSON(c, &reqBody); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 
 	var user model.User
-	if err := config.GetDB().Where("reset_token = ?", token).First(&user).Error; err != nil {
+	if err := config.GetDBContext(c.Request.Context()).Where("reset_token = ?", token).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			apperror.Abort(c, apperror.New(http.StatusUnauthorized, "invalid_token", "Invalid token"))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		apperror.Abort(c, apperror.Internal("Internal server error", err))
 		return
 	}
 
 	convertedExpTime := time.Unix(user.ResetExpTime, 0)
 	if convertedExpTime.Before(time.Now()) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has expired"})
+		apperror.Abort(c, apperror.New(http.StatusUnauthorized, "token_expired", "Token has expired"))
 		return
 	}
 
 	hash, err := argon2id.CreateHash(reqBody.Password, argon2id.DefaultParams)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		apperror.Abort(c, apperror.Internal("Failed to hash password", err))
 		return
 	}
 
 	user.Password = hash
 	user.ResetToken = ""
 	user.ResetExpTime = 0
-	if err := config.GetDB().Save(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+	if err := config.GetDBContext(c.Request.Context()).Save(&user).Error; err != nil {
+		apperror.Abort(c, apperror.Internal("Failed to reset password", err))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
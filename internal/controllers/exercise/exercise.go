@@ -4,25 +4,65 @@ import (
 	"net/http"
 	"workout_tracker/internal/config"
 	model "workout_tracker/internal/model/exercise"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/pagination"
 
 	"github.com/gin-gonic/gin"
 )
 
+type ExerciseListQuery struct {
+	pagination.Params
+	Category    int    `form:"category" binding:"omitempty,gt=0"`
+	MuscleGroup string `form:"muscle_group" binding:"max=255"`
+	Measurement string `form:"measurement" binding:"omitempty,oneof=reps_load reps duration distance_duration distance_load"`
+}
+
+var exerciseSorts = pagination.Sortable{"name": "name", "created_at": "created_at"}
+
 // @Tags Exercise
 // @Summary Get all exercises
-// @Description Get a list of all exercises
+// @Description Get a page of the exercise catalog
+// @Param limit query int false "Page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "name or created_at, prefixed with - for descending" default(name)
+// @Param category query int false "Only exercises in this category"
+// @Param muscle_group query string false "Only exercises for this muscle group"
+// @Param measurement query string false "Only exercises measured by reps_load, reps, duration, distance_duration or distance_load"
 // @Accept json
 // @Produce json
 // @Success 200 {array} model.Exercise
-// @Failure 500 {object} map[string]string
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /exercises [get]
 func GetAllExercises(c *gin.Context) {
-	var data []model.Exercise
-	if err := config.GetDB().Find(&data).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var query ExerciseListQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	page, pageErr := pagination.New(query.Params, exerciseSorts, "name")
+	if pageErr != nil {
+		apperror.Abort(c, pageErr)
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	if query.Category != 0 {
+		db = db.Where("category = ?", query.Category)
+	}
+	if query.MuscleGroup != "" {
+		db = db.Where("muscle_group = ?", query.MuscleGroup)
+	}
+	if query.Measurement != "" {
+		db = db.Where("measurement = ?", query.Measurement)
+	}
+	data, meta, err := pagination.Find[model.Exercise](db, page)
+	if err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to retrieve exercises"))
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "All exercises retrieved successfully", "data": data})
+	c.JSON(http.StatusOK, gin.H{"message": "All exercises retrieved successfully", "data": data, "pagination": meta})
 }
 
 // @Tags Exercise
@@ -31,12 +71,12 @@ func GetAllExercises(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {array} model.ExerciseCategory
-// @Failure 500 {object} map[string]string
+// @Failure 500 {object} apperror.Error
 // @Router /exercise-categories [get]
 func GetAllCategories(c *gin.Context) {
 	var data []model.ExerciseCategory
-	if err := config.GetDB().Find(&data).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := config.GetDBContext(c.Request.Context()).Find(&data).Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to retrieve exercise categories"))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "All exercise categories retrieved successfully", "data": data})
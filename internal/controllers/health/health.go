@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"workout_tracker/internal/config"
+	"workout_tracker/internal/migrate"
+
+	"github.com/gin-gonic/gin"
+)
+
+const checkTimeout = 2 * time.Second
+
+// Check is a single readiness probe. Optional checks cover dependencies the
+// API can run without, so their failure degrades but does not fail readiness.
+type Check struct {
+	Name     string
+	Optional bool
+	Run      func(ctx context.Context) error
+}
+
+// checkError is a check failure whose message is safe to show to anyone
+// calling the probe. Other errors may name hosts, ports or credentials, so
+// they are only logged and reported as unreachable.
+type checkError string
+
+func (e checkError) Error() string {
+	return string(e)
+}
+
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Optional  bool    `json:"optional,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Liveness reports that the process is up and serving requests. It checks
+// no dependencies so a slow database never gets the process restarted.
+func Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness runs every check concurrently and returns 503 when a required
+// check fails.
+func Readiness(c *gin.Context) {
+	checks := Checks()
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(c.Request.Context(), checkTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Run(ctx)
+			result := CheckResult{
+				Status:    "ok",
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+				Optional:  check.Optional,
+			}
+			if err != nil {
+				slog.WarnContext(c.Request.Context(), "Readiness check failed", "check", check.Name, "error", err)
+				result.Status = "fail"
+				result.Error = "unreachable"
+				var public checkError
+				if errors.As(err, &public) {
+					result.Error = public.Error()
+				}
+			}
+			results[i] = result
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{Status: "ok", Checks: map[string]CheckResult{}}
+	status := http.StatusOK
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status == "ok" {
+			continue
+		}
+		if check.Optional {
+			if report.Status == "ok" {
+				report.Status = "degraded"
+			}
+			continue
+		}
+		report.Status = "fail"
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// Checks returns the readiness checks for the configured dependencies. SMTP
+// and Redis are only checked when their environment variables are set.
+func Checks() []Check {
+	checks := []Check{
+		{Name: "database", Run: checkDatabase},
+		{Name: "migrations", Run: checkMigrations},
+	}
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		addr := net.JoinHostPort(host, os.Getenv("SMTP_PORT"))
+		checks = append(checks, Check{Name: "smtp", Optional: true, Run: func(ctx context.Context) error {
+			return dial(ctx, addr)
+		}})
+	}
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		checks = append(checks, Check{Name: "redis", Optional: true, Run: func(ctx context.Context) error {
+			return pingRedis(ctx, redisURL)
+		}})
+	}
+	return checks
+}
+
+func checkDatabase(ctx context.Context) error {
+	return config.GetDB().DB().PingContext(ctx)
+}
+
+func checkMigrations(ctx context.Context) error {
+	migrator, err := migrate.New(config.GetDB().DB(), config.Dialect())
+	if err != nil {
+		return err
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, s := range statuses {
+		if s.Modified {
+			return fmt.Errorf("migration %04d_%s was modified after it was applied: %w", s.Version, s.Name, checkError("modified migrations"))
+		}
+		if !s.Applied {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d %w", pending, checkError("pending migrations"))
+	}
+	return nil
+}
+
+func dial(ctx context.Context, addr string) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// pingRedis sends an inline PING command, which avoids pulling in a Redis
+// client just for the health check.
+func pingRedis(ctx context.Context, redisURL string) error {
+	u, err := url.Parse(redisURL)
+	if err != nil {
+		return err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "6379")
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if password, ok := u.User.Password(); ok {
+		if _, err := fmt.Fprintf(conn, "AUTH %s\r\n", password); err != nil {
+			return err
+		}
+		if err := expectReply(conn, "+OK"); err != nil {
+			return err
+		}
+	}
+	if _, err := conn.Write([]byte("PING\r\n")); err != nil {
+		return err
+	}
+	return expectReply(conn, "+PONG")
+}
+
+func expectReply(conn net.Conn, want string) error {
+	buf := make([]byte, 128)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return err
+	}
+	if reply := strings.TrimSpace(string(buf[:n])); !strings.HasPrefix(reply, want) {
+		return fmt.Errorf("unexpected reply %q", reply)
+	}
+	return nil
+}
@@ -4,17 +4,25 @@ import (
 	"net/http"
 	"workout_tracker/internal/config"
 	model "workout_tracker/internal/model/user"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
 	"workout_tracker/pkg/utils"
 
 	"github.com/alexedwards/argon2id"
 	"github.com/gin-gonic/gin"
-	"github.com/jinzhu/gorm"
 )
 
 type ChangePassword struct {
 	OldPassword     string `json:"old_password" binding:"required"`
-	NewPassword     string `json:"new_password" binding:"required"`
-	ConfirmPassword string `json:"confirm_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=72"`
+	ConfirmPassword string `json:"confirm_password" binding:"required,eqfield=NewPassword"`
+}
+
+// Preferences changes the preferences that are set, leaving the others.
+type Preferences struct {
+	PreferredUnits string   `json:"preferred_units" binding:"required_without_all=OneRMFormula TrainingDays,omitempty,oneof=metric imperial" example:"metric"`
+	OneRMFormula   string   `json:"one_rm_formula" binding:"omitempty,oneof=epley brzycki lombardi" example:"epley"`
+	TrainingDays   []string `json:"training_days" binding:"omitempty,max=7,unique,dive,oneof=mon tue wed thu fri sat sun" example:"mon,wed,fri"`
 }
 
 // @Tags User
@@ -23,31 +31,30 @@ type ChangePassword struct {
 // @Accept json
 // @Produce json
 // @Success 200 {object} model.User
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /users [get]
 func GetMyProfile(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
 	var user model.User
-	if err := config.GetDB().Where("ID = ?", userId).First(&user).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	if err := config.GetDBContext(c.Request.Context()).Where("id = ?", userId).First(&user).Error; err != nil {
+		apperror.Abort(c, apperror.Lookup(err, "user_not_found", "User not found"))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "User profile retrieved successfully", "data": gin.H{
-		"first_name":  user.FirstName,
-		"last_name":   user.LastName,
-		"email":       user.Email,
-		"is_verified": user.IsVerified,
+		"first_name":      user.FirstName,
+		"last_name":       user.LastName,
+		"email":           user.Email,
+		"is_verified":     user.IsVerified,
+		"preferred_units": user.PreferredUnits,
+		"one_rm_formula":  user.OneRMFormula,
+		"training_days":   user.TrainingDays,
 	}})
 }
 
@@ -58,58 +65,104 @@ func GetMyProfile(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 204 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /users/change-password [patch]
 func UpdatePassword(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
 	var reqBody ChangePassword
-	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
-		return
-	}
-	if reqBody.NewPassword != reqBody.ConfirmPassword {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "New password and confirm password do not match"})
+	if err := validation.BindJSON(c, &reqBody); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 
 	var user model.User
-	if err := config.GetDB().Where("ID = ?", userId).First(&user).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	if err := config.GetDBContext(c.Request.Context()).Where("id = ?", userId).First(&user).Error; err != nil {
+		apperror.Abort(c, apperror.Lookup(err, "user_not_found", "User not found"))
 		return
 	}
 
 	verifyPassword, err := argon2id.ComparePasswordAndHash(reqBody.OldPassword, user.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error verifying password"})
+		apperror.Abort(c, apperror.Internal("Error verifying password", err))
 		return
 	}
 	if !verifyPassword {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid old password"})
+		apperror.Abort(c, apperror.New(http.StatusUnauthorized, "invalid_old_password", "Invalid old password"))
 		return
 	}
 
 	hash, err := argon2id.CreateHash(reqBody.NewPassword, argon2id.DefaultParams)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error hashing new password"})
+		apperror.Abort(c, apperror.Internal("Error hashing new password", err))
 		return
 	}
 
 	user.Password = hash
-	if err := config.GetDB().Save(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	if err := config.GetDBContext(c.Request.Context()).Save(&user).Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Internal server error"))
 		return
 	}
 
 	c.JSON(http.StatusAccepted, gin.H{"message": "Password updated successfully"})
 }
+
+// @Tags User
+// @Summary Update user preferences
+// @Description Set the unit system, metric (kg, m) or imperial (lb, mi, ft), that the authenticated user's loads and distances are entered and shown in, the formula, epley, brzycki or lombardi, their one-rep maxes are estimated with, and the days of the week, mon to sun, they train on. An empty list of training days clears them
+// @Param request body Preferences true "Preferences"
+// @Accept json
+// @Produce json
+// @Success 200 {object} Preferences
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /users/preferences [patch]
+func UpdatePreferences(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	var reqBody Preferences
+	if err := validation.BindJSON(c, &reqBody); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if reqBody.PreferredUnits != "" {
+		updates["preferred_units"] = reqBody.PreferredUnits
+	}
+	if reqBody.OneRMFormula != "" {
+		updates["one_rm_formula"] = reqBody.OneRMFormula
+	}
+	if reqBody.TrainingDays != nil {
+		updates["training_days"] = model.Weekdays(reqBody.TrainingDays)
+	}
+	db := config.GetDBContext(c.Request.Context())
+	if err := db.Model(&model.User{}).Where("id = ?", userId).Updates(updates).Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to update preferences"))
+		return
+	}
+
+	var user model.User
+	if err := db.Select("preferred_units, one_rm_formula, training_days").First(&user, userId).Error; err != nil {
+		apperror.Abort(c, apperror.Lookup(err, "user_not_found", "User not found"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Preferences updated successfully", "data": Preferences{
+		PreferredUnits: user.PreferredUnits,
+		OneRMFormula:   user.OneRMFormula,
+		TrainingDays:   user.TrainingDays,
+	}})
+}
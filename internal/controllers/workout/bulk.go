@@ -0,0 +1,323 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"workout_tracker/internal/config"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/etag"
+	"workout_tracker/pkg/metrics"
+	"workout_tracker/pkg/units"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+// Bulk modes. An atomic request applies every operation or none; a partial
+// one applies each operation that succeeds.
+const (
+	BulkAtomic  = "atomic"
+	BulkPartial = "partial"
+)
+
+type BulkRequest struct {
+	Mode       string          `json:"mode" binding:"omitempty,oneof=atomic partial" example:"atomic"`
+	Operations []BulkOperation `json:"operations" binding:"required,min=1,max=500,dive"`
+}
+
+// BulkOperation creates a resource from data, applies data to one as a JSON
+// Merge Patch, or deletes one. if_match makes an update or delete
+// conditional on the resource's ETag.
+type BulkOperation struct {
+	Op      string          `json:"op" binding:"required,oneof=create update delete" example:"create"`
+	ID      uint            `json:"id" binding:"required_unless=Op create"`
+	IfMatch string          `json:"if_match"`
+	Data    json.RawMessage `json:"data" binding:"required_unless=Op delete" swaggertype:"object"`
+}
+
+type BulkResult struct {
+	Index  int             `json:"index"`
+	Op     string          `json:"op"`
+	ID     uint            `json:"id,omitempty"`
+	Status int             `json:"status"`
+	Data   interface{}     `json:"data,omitempty"`
+	Error  *apperror.Error `json:"error,omitempty"`
+
+	// completed is set when the operation completed a schedule.
+	completed bool
+}
+
+type BulkResponse struct {
+	Mode      string       `json:"mode"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Results   []BulkResult `json:"results"`
+}
+
+// bulkApply applies one operation through tx. ctx carries tx for validators.
+type bulkApply func(ctx context.Context, tx *gorm.DB, op BulkOperation) BulkResult
+
+// @Tags Workout
+// @Summary Create, update and delete workout plans in bulk
+// @Description Apply up to 500 operations to the authenticated user's workout plans. In atomic mode (the default) they run in one transaction and either all apply or none do; in partial mode each is applied on its own. Every operation gets a result with its own status and, if it failed, a problem. Responds 200 when every operation applied, 207 when a partial request had failures, and 422 when an atomic request was rolled back.
+// @Param request body BulkRequest true "Operations; data is a WorkoutPlan for create and a merge patch of a WorkoutPlanPatch for update"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} BulkResponse
+// @Success 207 {object} BulkResponse
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 422 {object} BulkResponse
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/bulk [post]
+func BulkWorkouts(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	system, unitErr := unitSystem(c, config.GetDBContext(c.Request.Context()), userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	var req BulkRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	response, status, bulkErr := runBulk(c, req, func(ctx context.Context, tx *gorm.DB, op BulkOperation) BulkResult {
+		result := BulkResult{Op: op.Op, ID: op.ID}
+		if op.Op == "create" {
+			var input WorkoutPlan
+			if err := validation.Decode(ctx, op.Data, &input); err != nil {
+				return result.fail(err)
+			}
+			units.ToSI(system, &input)
+			workout, err := createWorkout(tx, userId, input)
+			if err != nil {
+				return result.fail(err)
+			}
+			units.FromSI(system, &workout)
+			return result.ok(http.StatusCreated, workout.ID, workout)
+		}
+
+		workout, err := findWorkout(tx, userId, op.ID)
+		if err != nil {
+			return result.fail(err)
+		}
+		if err := etag.CheckIfMatch(op.IfMatch, workout.Version); err != nil {
+			return result.fail(err)
+		}
+		if op.Op == "delete" {
+			if err := deleteWorkout(tx, &workout, op.IfMatch); err != nil {
+				return result.fail(err)
+			}
+			return result.ok(http.StatusNoContent, workout.ID, nil)
+		}
+		input := editableWorkout(workout)
+		if err := validation.Patch(ctx, &input, validation.MergePatchType, op.Data); err != nil {
+			return result.fail(err)
+		}
+		if err := saveWorkout(tx, &workout, input, op.IfMatch); err != nil {
+			return result.fail(err)
+		}
+		units.FromSI(system, &workout)
+		return result.ok(http.StatusOK, workout.ID, workout)
+	})
+	if bulkErr != nil {
+		apperror.Abort(c, bulkErr)
+		return
+	}
+
+	for _, result := range response.Results {
+		if result.Op == "create" && result.Error == nil {
+			metrics.WorkoutsCreated.Inc()
+		}
+	}
+	c.JSON(status, gin.H{"message": "Bulk workout operations processed", "data": response})
+}
+
+// @Tags Workout
+// @Summary Create, update and delete workout schedules in bulk
+// @Description Apply up to 500 operations to the authenticated user's workout schedules, with the same modes and results as POST /workouts/bulk.
+// @Param request body BulkRequest true "Operations; data is a WorkoutSchedule for create and a merge patch of one for update"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Accept json
+// @Produce json
+// @Success 200 {object} BulkResponse
+// @Success 207 {object} BulkResponse
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 422 {object} BulkResponse
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/schedules/bulk [post]
+func BulkSchedules(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	c.Set(validation.UserIDKey, userId)
+	var req BulkRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	response, status, bulkErr := runBulk(c, req, func(ctx context.Context, tx *gorm.DB, op BulkOperation) BulkResult {
+		result := BulkResult{Op: op.Op, ID: op.ID}
+		if op.Op == "create" {
+			var input WorkoutSchedule
+			if err := validation.Decode(ctx, op.Data, &input); err != nil {
+				return result.fail(err)
+			}
+			schedule := newWorkoutSchedule(userId, input)
+			if err := tx.Create(&schedule).Error; err != nil {
+				return result.fail(apperror.Database(err, "Failed to create schedule"))
+			}
+			result.completed = schedule.Status == model.StatusCompleted
+			return result.ok(http.StatusCreated, schedule.ID, schedule)
+		}
+
+		schedule, err := findSchedule(tx, userId, op.ID)
+		if err != nil {
+			return result.fail(err)
+		}
+		if err := etag.CheckIfMatch(op.IfMatch, schedule.Version); err != nil {
+			return result.fail(err)
+		}
+		if op.Op == "delete" {
+			if err := deleteSchedule(tx, &schedule, op.IfMatch); err != nil {
+				return result.fail(err)
+			}
+			return result.ok(http.StatusNoContent, schedule.ID, nil)
+		}
+		wasCompleted := schedule.Status == model.StatusCompleted
+		input := editableSchedule(schedule)
+		if err := validation.Patch(ctx, &input, validation.MergePatchType, op.Data); err != nil {
+			return result.fail(err)
+		}
+		if err := saveSchedule(tx, &schedule, input, op.IfMatch); err != nil {
+			return result.fail(err)
+		}
+		result.completed = !wasCompleted && schedule.Status == model.StatusCompleted
+		return result.ok(http.StatusOK, schedule.ID, schedule)
+	})
+	if bulkErr != nil {
+		apperror.Abort(c, bulkErr)
+		return
+	}
+
+	for _, result := range response.Results {
+		if result.Op == "create" && result.Error == nil {
+			metrics.SchedulesCreated.Inc()
+		}
+		if result.completed {
+			metrics.SchedulesCompleted.Inc()
+		}
+	}
+	c.JSON(status, gin.H{"message": "Bulk schedule operations processed", "data": response})
+}
+
+// runBulk applies the operations of req and returns their results with the
+// HTTP status for the response. Atomic requests run in a single transaction
+// that is rolled back if any operation fails; the operations that did not
+// fail are then reported as 424 Failed Dependency. Partial requests run
+// each operation in its own transaction.
+func runBulk(c *gin.Context, req BulkRequest, apply bulkApply) (BulkResponse, int, *apperror.Error) {
+	ctx := validation.Context(c)
+	db := config.GetDBContext(c.Request.Context())
+	response := BulkResponse{Mode: req.Mode, Results: make([]BulkResult, len(req.Operations))}
+	if response.Mode == "" {
+		response.Mode = BulkAtomic
+	}
+
+	if response.Mode == BulkPartial {
+		for i, op := range req.Operations {
+			tx := db.Begin()
+			result := apply(validation.WithDB(ctx, tx), tx, op)
+			if result.Error != nil {
+				tx.Rollback()
+			} else if err := tx.Commit().Error; err != nil {
+				result = result.fail(apperror.Database(err, "Failed to commit operation"))
+			}
+			result.Index = i
+			response.Results[i] = result
+		}
+	} else {
+		tx := db.Begin()
+		failed := -1
+		for i, op := range req.Operations {
+			result := apply(validation.WithDB(ctx, tx), tx, op)
+			result.Index = i
+			response.Results[i] = result
+			if result.Error != nil {
+				failed = i
+				break
+			}
+		}
+		if failed < 0 {
+			if err := tx.Commit().Error; err != nil {
+				return response, 0, apperror.Database(err, "Failed to commit bulk operations")
+			}
+		} else {
+			tx.Rollback()
+			for i, op := range req.Operations {
+				if i == failed {
+					continue
+				}
+				detail := fmt.Sprintf("Not applied because operation %d failed", failed)
+				response.Results[i] = BulkResult{Index: i, Op: op.Op, ID: op.ID}.fail(
+					apperror.New(http.StatusFailedDependency, "bulk_rolled_back", detail))
+			}
+		}
+	}
+
+	for _, result := range response.Results {
+		if result.Error != nil {
+			if result.Error.Status >= http.StatusInternalServerError {
+				slog.ErrorContext(ctx, result.Error.Detail, "code", result.Error.Code, "index", result.Index, "error", result.Error.Unwrap())
+			}
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+	switch {
+	case response.Failed == 0:
+		return response, http.StatusOK, nil
+	case response.Mode == BulkPartial:
+		return response, http.StatusMultiStatus, nil
+	default:
+		return response, http.StatusUnprocessableEntity, nil
+	}
+}
+
+func (r BulkResult) ok(status int, id uint, data interface{}) BulkResult {
+	r.Status = status
+	r.ID = id
+	r.Data = data
+	return r
+}
+
+func (r BulkResult) fail(err *apperror.Error) BulkResult {
+	r.Status = err.Status
+	r.Error = err
+	r.Data = nil
+	r.completed = false
+	return r
+}
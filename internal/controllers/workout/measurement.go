@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"fmt"
+	exeModel "workout_tracker/internal/model/exercise"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/units"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Cardio is the time and distance measures of a target or a logged set. The
+// duration is in seconds, distance, elevation and pace are in the units of
+// the request, and heart_rate is the average in beats per minute.
+type Cardio struct {
+	DurationSeconds int64         `json:"duration_seconds" binding:"gte=0,max=86400"`
+	Distance        units.Decimal `json:"distance" binding:"gte=0,max_distance=1000000" unit:"distance"`
+	Pace            int64         `json:"pace" binding:"gte=0,max_pace=3600" unit:"pace"`
+	Elevation       units.Decimal `json:"elevation" binding:"gte=0,max_elevation=30000" unit:"elevation"`
+	HeartRate       int64         `json:"heart_rate" binding:"omitempty,min=30,max=250"`
+	Calories        int64         `json:"calories" binding:"gte=0,max=20000"`
+}
+
+func newCardio(input Cardio) model.Cardio {
+	return model.Cardio{
+		DurationSeconds:  input.DurationSeconds,
+		DistanceMeters:   input.Distance,
+		PaceSecondsPerKm: input.Pace,
+		ElevationMeters:  input.Elevation,
+		HeartRate:        input.HeartRate,
+		Calories:         input.Calories,
+	}
+}
+
+// editableCardio returns the measures of cardio in SI units.
+func editableCardio(cardio model.Cardio) Cardio {
+	return Cardio{
+		DurationSeconds: cardio.DurationSeconds,
+		Distance:        cardio.DistanceMeters,
+		Pace:            cardio.PaceSecondsPerKm,
+		Elevation:       cardio.ElevationMeters,
+		HeartRate:       cardio.HeartRate,
+		Calories:        cardio.Calories,
+	}
+}
+
+// cardioColumns maps the columns of the measures in cardio to their values,
+// for updates that must also clear measures set back to zero.
+func cardioColumns(cardio model.Cardio) map[string]interface{} {
+	return map[string]interface{}{
+		"duration_seconds":    cardio.DurationSeconds,
+		"distance_meters":     cardio.DistanceMeters,
+		"pace_seconds_per_km": cardio.PaceSecondsPerKm,
+		"elevation_meters":    cardio.ElevationMeters,
+		"heart_rate":          cardio.HeartRate,
+		"calories":            cardio.Calories,
+	}
+}
+
+// measurements returns the measurement kind of each of the given exercises.
+func measurements(db *gorm.DB, exerciseIds ...int64) (map[int64]string, *apperror.Error) {
+	var exercises []exeModel.Exercise
+	if err := db.Select("id, measurement").Where("id IN (?)", exerciseIds).Find(&exercises).Error; err != nil {
+		return nil, apperror.Database(err, "Failed to retrieve exercises")
+	}
+	kinds := make(map[int64]string, len(exercises))
+	for _, exercise := range exercises {
+		kinds[int64(exercise.ID)] = exercise.Measurement
+	}
+	return kinds, nil
+}
+
+// checkMeasures reports the measure an exercise of the given kind is tracked
+// by when a target or logged set leaves it at zero. path prefixes the field
+// names and repsField is the name of the reps field; reps are not required
+// when skipReps is set, as for an AMRAP set.
+func checkMeasures(kind, path, repsField string, reps int64, skipReps bool, cardio Cardio) []apperror.FieldError {
+	missing := func(field, message string) []apperror.FieldError {
+		return []apperror.FieldError{{Field: path + field, Code: "required", Message: message}}
+	}
+	switch kind {
+	case exeModel.MeasureRepsLoad, exeModel.MeasureReps:
+		if reps == 0 && !skipReps {
+			return missing(repsField, "is required for exercises measured by reps")
+		}
+	case exeModel.MeasureDuration:
+		if cardio.DurationSeconds == 0 {
+			return missing("duration_seconds", "is required for exercises measured by duration")
+		}
+	case exeModel.MeasureDistanceDuration:
+		if cardio.Distance == 0 && cardio.DurationSeconds == 0 {
+			return missing("distance", "or duration_seconds is required for exercises measured by distance and duration")
+		}
+	case exeModel.MeasureDistanceLoad:
+		if cardio.Distance == 0 {
+			return missing("distance", "is required for exercises measured by distance")
+		}
+	}
+	return nil
+}
+
+// checkPlanExerciseMeasures checks the targets of a plan exercise, or those
+// of each of its prescriptions, against the exercise's measurement kind.
+func checkPlanExerciseMeasures(kind, path string, input WorkoutPlanExercise) []apperror.FieldError {
+	if input.Prescriptions == nil {
+		return checkMeasures(kind, path, "repetitions", input.Repetitions, false, input.Cardio)
+	}
+	var fields []apperror.FieldError
+	for i, set := range input.Prescriptions {
+		skipReps := set.Type == model.SetAMRAP || set.Type == model.SetFailure
+		setPath := fmt.Sprintf("%sprescriptions[%d].", path, i)
+		fields = append(fields, checkMeasures(kind, setPath, "reps", set.Reps, skipReps, set.Cardio)...)
+	}
+	return fields
+}
+
+// noPath reports fields without a prefix, for a single exercise in the body.
+func noPath(int) string { return "" }
+
+// checkPlanExercisesMeasures checks the targets of each of exercises against
+// the measurement kind of its exercise. path returns the prefix of the field
+// names of the i-th exercise.
+func checkPlanExercisesMeasures(db *gorm.DB, path func(i int) string, exercises ...WorkoutPlanExercise) *apperror.Error {
+	ids := make([]int64, len(exercises))
+	for i, exercise := range exercises {
+		ids[i] = exercise.ExerciseId
+	}
+	kinds, err := measurements(db, ids...)
+	if err != nil {
+		return err
+	}
+	var fields []apperror.FieldError
+	for i, exercise := range exercises {
+		fields = append(fields, checkPlanExerciseMeasures(kinds[exercise.ExerciseId], path(i), exercise)...)
+	}
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
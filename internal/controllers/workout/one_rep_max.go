@@ -0,0 +1,490 @@
+package controllers
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"time"
+	"workout_tracker/internal/config"
+	exeModel "workout_tracker/internal/model/exercise"
+	userModel "workout_tracker/internal/model/user"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/units"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+// OneRepMax is a user's max on an exercise: the one-rep max estimated from
+// their best set, and the training max that percentage-based prescriptions
+// are taken of, which is the estimate unless the user set their own. Either
+// is null while unknown.
+type OneRepMax struct {
+	ExerciseId            int64          `json:"exercise_id"`
+	Formula               string         `json:"formula"`
+	Estimated1RM          *units.Decimal `json:"estimated_1rm" unit:"load"`
+	TrainingMax           *units.Decimal `json:"training_max" unit:"load"`
+	TrainingMaxOverridden bool           `json:"training_max_overridden"`
+}
+
+// OneRepMaxPoint is the best one-rep max estimated from the sets of a
+// session, and the set it was estimated from.
+type OneRepMaxPoint struct {
+	WorkoutSessionId int64         `json:"workout_session_id"`
+	Date             time.Time     `json:"date"`
+	Estimated1RM     units.Decimal `json:"estimated_1rm" unit:"load"`
+	Weight           units.Decimal `json:"weight" unit:"load"`
+	Reps             int64         `json:"reps"`
+}
+
+// TrainingMax sets the training max of an exercise, in the units of the
+// request.
+type TrainingMax struct {
+	TrainingMax units.Decimal `json:"training_max" binding:"required,gt=0,max_load=2000" unit:"load"`
+}
+
+type OneRepMaxQuery struct {
+	Formula string `form:"formula" binding:"omitempty,oneof=epley brzycki lombardi"`
+}
+
+type OneRepMaxHistoryQuery struct {
+	OneRepMaxQuery
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02" binding:"omitempty,gtefield=From"`
+}
+
+// maxEstimatedReps is the most reps a set can have for a one-rep max to be
+// estimated from it; the formulas lose accuracy beyond.
+const maxEstimatedReps = 12
+
+// oneRMFormulas estimate a one-rep max from a weight lifted for reps.
+var oneRMFormulas = map[string]func(weight float64, reps int64) float64{
+	userModel.FormulaEpley: func(weight float64, reps int64) float64 {
+		return weight * (1 + float64(reps)/30)
+	},
+	userModel.FormulaBrzycki: func(weight float64, reps int64) float64 {
+		return weight * 36 / float64(37-reps)
+	},
+	userModel.FormulaLombardi: func(weight float64, reps int64) float64 {
+		return weight * math.Pow(float64(reps), 0.1)
+	},
+}
+
+// loadSteps are the increments resolved loads are rounded to, in the units
+// of each system.
+var loadSteps = map[units.System]float64{units.Metric: 2.5, units.Imperial: 5}
+
+// @Tags Workout
+// @Summary Get user one-rep maxes
+// @Description Get the estimated one-rep max and the training max of the authenticated user on every exercise they logged a set of, or set a training max for. One-rep maxes are estimated from the best set of 1 to 12 reps.
+// @Param formula query string false "epley, brzycki or lombardi; defaults to the user's formula"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {array} OneRepMax
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /one-rep-maxes [get]
+func GetOneRepMaxes(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	var query OneRepMaxQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	formula, formulaErr := oneRMFormula(db, userId, query.Formula)
+	if formulaErr != nil {
+		apperror.Abort(c, formulaErr)
+		return
+	}
+
+	maxes, maxErr := oneRepMaxes(db, userId, formula)
+	if maxErr != nil {
+		apperror.Abort(c, maxErr)
+		return
+	}
+	response := make([]OneRepMax, 0, len(maxes))
+	for _, oneRepMax := range maxes {
+		response = append(response, oneRepMax)
+	}
+	sort.Slice(response, func(i, j int) bool { return response[i].ExerciseId < response[j].ExerciseId })
+	units.FromSI(system, &response)
+	c.JSON(http.StatusOK, gin.H{"message": "One-rep maxes retrieved successfully", "data": response})
+}
+
+// @Tags Workout
+// @Summary Get user one-rep max on an exercise
+// @Description Get the estimated one-rep max and the training max of the authenticated user on an exercise
+// @Param exercise_id path int true "Exercise ID"
+// @Param formula query string false "epley, brzycki or lombardi; defaults to the user's formula"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} OneRepMax
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /one-rep-maxes/{exercise_id} [get]
+func GetOneRepMax(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	var query OneRepMaxQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	exercise, lookupErr := findExercise(db, c.Param("exercise_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	respondOneRepMax(c, db, system, userId, query.Formula, int64(exercise.ID), "One-rep max retrieved successfully")
+}
+
+// @Tags Workout
+// @Summary Set user training max on an exercise
+// @Description Set the training max of the authenticated user on an exercise, which prescriptions given as a percentage of the one-rep max are then taken of instead of the estimated one-rep max
+// @Param exercise_id path int true "Exercise ID"
+// @Param training_max body TrainingMax true "Training max"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} OneRepMax
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /one-rep-maxes/{exercise_id}/training-max [put]
+func SetTrainingMax(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	exercise, lookupErr := findExercise(db, c.Param("exercise_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	var input TrainingMax
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	units.ToSI(system, &input)
+
+	trainingMax := model.TrainingMax{UserId: userId, ExerciseId: int64(exercise.ID)}
+	if err := db.Where(trainingMax).Assign(model.TrainingMax{Weight: input.TrainingMax}).
+		FirstOrCreate(&trainingMax).Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to set training max"))
+		return
+	}
+	respondOneRepMax(c, db, system, userId, "", trainingMax.ExerciseId, "Training max set successfully")
+}
+
+// @Tags Workout
+// @Summary Clear user training max on an exercise
+// @Description Clear the training max the authenticated user set on an exercise, going back to the estimated one-rep max
+// @Param exercise_id path int true "Exercise ID"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} OneRepMax
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /one-rep-maxes/{exercise_id}/training-max [delete]
+func ClearTrainingMax(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	exercise, lookupErr := findExercise(db, c.Param("exercise_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := db.Where("user_id = ? AND exercise_id = ?", userId, exercise.ID).Delete(&model.TrainingMax{}).Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to clear training max"))
+		return
+	}
+	respondOneRepMax(c, db, system, userId, "", int64(exercise.ID), "Training max cleared successfully")
+}
+
+// @Tags Workout
+// @Summary Get user one-rep max history on an exercise
+// @Description Get the best one-rep max of the authenticated user estimated from each of their sessions with sets of an exercise, oldest first, to chart its progress
+// @Param exercise_id path int true "Exercise ID"
+// @Param formula query string false "epley, brzycki or lombardi; defaults to the user's formula"
+// @Param from query string false "Only sessions started on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Only sessions started on or before this date (YYYY-MM-DD)"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {array} OneRepMaxPoint
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /one-rep-maxes/{exercise_id}/history [get]
+func GetOneRepMaxHistory(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	var query OneRepMaxHistoryQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	formula, formulaErr := oneRMFormula(db, userId, query.Formula)
+	if formulaErr != nil {
+		apperror.Abort(c, formulaErr)
+		return
+	}
+	exercise, lookupErr := findExercise(db, c.Param("exercise_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+
+	sets := liftSets(db, userId).Where("workout_session_sets.exercise_id = ?", exercise.ID)
+	if !query.From.IsZero() {
+		sets = sets.Where("workout_sessions.started_at >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		sets = sets.Where("workout_sessions.started_at < ?", query.To.AddDate(0, 0, 1))
+	}
+	var rows []liftSet
+	if err := sets.Select("workout_sessions.id AS workout_session_id, workout_sessions.started_at, workout_session_sets.reps, MAX(workout_session_sets.weight) AS weight").
+		Group("workout_sessions.id, workout_sessions.started_at, workout_session_sets.reps").
+		Order("workout_sessions.started_at, workout_sessions.id").Scan(&rows).Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to retrieve one-rep max history"))
+		return
+	}
+
+	history := []OneRepMaxPoint{}
+	for _, row := range rows {
+		e1rm := estimate1RM(formula, row.Weight, row.Reps)
+		last := len(history) - 1
+		if last >= 0 && history[last].WorkoutSessionId == row.WorkoutSessionId {
+			if e1rm > history[last].Estimated1RM {
+				history[last].Estimated1RM, history[last].Weight, history[last].Reps = e1rm, row.Weight, row.Reps
+			}
+			continue
+		}
+		history = append(history, OneRepMaxPoint{
+			WorkoutSessionId: row.WorkoutSessionId,
+			Date:             row.StartedAt,
+			Estimated1RM:     e1rm,
+			Weight:           row.Weight,
+			Reps:             row.Reps,
+		})
+	}
+	units.FromSI(system, &history)
+	c.JSON(http.StatusOK, gin.H{"message": "One-rep max history retrieved successfully", "data": history})
+}
+
+// respondOneRepMax responds with the user's one-rep max on an exercise.
+func respondOneRepMax(c *gin.Context, db *gorm.DB, system units.System, userId int64, formula string, exerciseId int64, message string) {
+	formula, formulaErr := oneRMFormula(db, userId, formula)
+	if formulaErr != nil {
+		apperror.Abort(c, formulaErr)
+		return
+	}
+	maxes, maxErr := oneRepMaxes(db, userId, formula, exerciseId)
+	if maxErr != nil {
+		apperror.Abort(c, maxErr)
+		return
+	}
+	response := maxes[exerciseId]
+	response.ExerciseId, response.Formula = exerciseId, formula
+	units.FromSI(system, &response)
+	c.JSON(http.StatusOK, gin.H{"message": message, "data": response})
+}
+
+// estimate1RM estimates the one-rep max from a weight lifted for reps, or
+// returns zero when it cannot be estimated from such a set.
+func estimate1RM(formula string, weight units.Decimal, reps int64) units.Decimal {
+	estimate, ok := oneRMFormulas[formula]
+	if !ok || weight <= 0 || reps < 1 || reps > maxEstimatedReps {
+		return 0
+	}
+	if reps == 1 {
+		return weight
+	}
+	return units.NewDecimal(estimate(weight.Float64(), reps))
+}
+
+// oneRMFormula returns formula if set, otherwise the user's formula.
+func oneRMFormula(db *gorm.DB, userId int64, formula string) (string, *apperror.Error) {
+	if formula != "" {
+		return formula, nil
+	}
+	var user userModel.User
+	if err := db.Select("one_rm_formula").First(&user, userId).Error; err != nil {
+		return "", apperror.Lookup(err, "user_not_found", "User not found")
+	}
+	return user.OneRMFormula, nil
+}
+
+// liftSet is the heaviest weight a user lifted for a number of reps on an
+// exercise, in a session or overall.
+type liftSet struct {
+	ExerciseId       int64
+	WorkoutSessionId int64
+	StartedAt        time.Time
+	Reps             int64
+	Weight           units.Decimal
+}
+
+// liftSets selects the sets logged by the user that a one-rep max can be
+// estimated from.
+func liftSets(db *gorm.DB, userId int64) *gorm.DB {
+	return db.Table("workout_session_sets").
+		Joins("JOIN workout_sessions ON workout_sessions.id = workout_session_sets.workout_session_id").
+		Where("workout_sessions.user_id = ? AND workout_sessions.deleted_at IS NULL", userId).
+		Where("workout_session_sets.weight > 0 AND workout_session_sets.reps BETWEEN 1 AND ?", maxEstimatedReps)
+}
+
+// oneRepMaxes returns the user's one-rep maxes on the given exercises, or on
+// every exercise they have one on when none are given.
+func oneRepMaxes(db *gorm.DB, userId int64, formula string, exerciseIds ...int64) (map[int64]OneRepMax, *apperror.Error) {
+	sets := liftSets(db, userId)
+	trainingMaxes := db.Where("user_id = ?", userId)
+	if len(exerciseIds) > 0 {
+		sets = sets.Where("workout_session_sets.exercise_id IN (?)", exerciseIds)
+		trainingMaxes = trainingMaxes.Where("exercise_id IN (?)", exerciseIds)
+	}
+	var rows []liftSet
+	if err := sets.Select("workout_session_sets.exercise_id, workout_session_sets.reps, MAX(workout_session_sets.weight) AS weight").
+		Group("workout_session_sets.exercise_id, workout_session_sets.reps").Scan(&rows).Error; err != nil {
+		return nil, apperror.Database(err, "Failed to estimate one-rep maxes")
+	}
+	var overrides []model.TrainingMax
+	if err := trainingMaxes.Find(&overrides).Error; err != nil {
+		return nil, apperror.Database(err, "Failed to retrieve training maxes")
+	}
+
+	maxes := make(map[int64]OneRepMax)
+	for _, row := range rows {
+		oneRepMax := maxes[row.ExerciseId]
+		if e1rm := estimate1RM(formula, row.Weight, row.Reps); oneRepMax.Estimated1RM == nil || e1rm > *oneRepMax.Estimated1RM {
+			oneRepMax.Estimated1RM = &e1rm
+			oneRepMax.TrainingMax = &e1rm
+		}
+		oneRepMax.ExerciseId, oneRepMax.Formula = row.ExerciseId, formula
+		maxes[row.ExerciseId] = oneRepMax
+	}
+	for _, override := range overrides {
+		oneRepMax := maxes[override.ExerciseId]
+		weight := override.Weight
+		oneRepMax.ExerciseId, oneRepMax.Formula = override.ExerciseId, formula
+		oneRepMax.TrainingMax, oneRepMax.TrainingMaxOverridden = &weight, true
+		maxes[override.ExerciseId] = oneRepMax
+	}
+	return maxes, nil
+}
+
+// resolvePercentLoads sets the weight of the prescriptions of workout given
+// as a percentage of the one-rep max, from the user's training maxes,
+// rounded to the plates of system. Prescriptions on an exercise without a
+// training max are left without a weight.
+func resolvePercentLoads(db *gorm.DB, userId int64, system units.System, workout *model.WorkoutPlan) *apperror.Error {
+	var exerciseIds []int64
+	for _, exercise := range workout.Exercises {
+		for _, set := range exercise.Prescriptions {
+			if set.Percent1RM != nil {
+				exerciseIds = append(exerciseIds, exercise.ExerciseId)
+				break
+			}
+		}
+	}
+	if len(exerciseIds) == 0 {
+		return nil
+	}
+	formula, err := oneRMFormula(db, userId, "")
+	if err != nil {
+		return err
+	}
+	maxes, err := oneRepMaxes(db, userId, formula, exerciseIds...)
+	if err != nil {
+		return err
+	}
+	for i := range workout.Exercises {
+		exercise := &workout.Exercises[i]
+		trainingMax := maxes[exercise.ExerciseId].TrainingMax
+		if trainingMax == nil {
+			continue
+		}
+		for j := range exercise.Prescriptions {
+			set := &exercise.Prescriptions[j]
+			if set.Percent1RM == nil {
+				continue
+			}
+			load := units.NewDecimal(trainingMax.Float64() * float64(*set.Percent1RM) / 100)
+			load = units.Round(system, units.Load, load, loadSteps[system])
+			set.Weight = &load
+		}
+	}
+	return nil
+}
+
+// findExercise loads the exercise with the given id.
+func findExercise(db *gorm.DB, id string) (exeModel.Exercise, *apperror.Error) {
+	var exercise exeModel.Exercise
+	if err := db.First(&exercise, map[string]interface{}{"id": id}).Error; err != nil {
+		return exercise, apperror.Lookup(err, "exercise_not_found", "Exercise not found")
+	}
+	return exercise, nil
+}
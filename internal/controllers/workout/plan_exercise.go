@@ -0,0 +1,651 @@
+package controllers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"workout_tracker/internal/config"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/etag"
+	"workout_tracker/pkg/units"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+// maxPlanExercises caps the number of exercises in a workout plan.
+const maxPlanExercises = 50
+
+// WorkoutPlanExercise prescribes either sets straight sets of repetitions
+// at weight, or the sets listed in prescriptions. With prescriptions, sets,
+// repetitions and weight are derived from them: the number of sets, their
+// average target reps and their heaviest absolute weight.
+// Which of repetitions, duration_seconds and distance must be set depends on
+// how the exercise is measured. Weights are in the units of the request.
+// progression optionally moves the load after each session following the
+// plan.
+type WorkoutPlanExercise struct {
+	ExerciseId    int64             `json:"exercise_id" binding:"required,gt=0,exercise"`
+	Sets          int64             `json:"sets" binding:"required_without=Prescriptions,gte=0,max=100"`
+	Repetitions   int64             `json:"repetitions" binding:"gte=0,max=1000"`
+	Weight        units.Decimal     `json:"weight" binding:"gte=0,max_load=2000" unit:"load"`
+	RestSeconds   int64             `json:"rest_seconds" binding:"gte=0,max=3600"`
+	Notes         string            `json:"notes" binding:"max=1000"`
+	Prescriptions []SetPrescription `json:"prescriptions" binding:"omitempty,min=1,max=30,dive"`
+	Progression   *Progression      `json:"progression"`
+	Cardio
+}
+
+// SetPrescription is one set of a plan exercise. Reps is the target, or the
+// bottom of a rep range when max_reps is set; AMRAP and failure sets, and
+// sets of exercises not measured by reps, may leave it out. The load is an
+// absolute weight or a percentage of the one-rep max, and may be capped by an
+// RPE or a reps-in-reserve target.
+type SetPrescription struct {
+	Type        string         `json:"type" binding:"required,oneof=warmup working drop failure amrap" example:"working"`
+	Reps        int64          `json:"reps" binding:"gte=0,max=1000"`
+	MaxReps     int64          `json:"max_reps" binding:"omitempty,gtefield=Reps,max=1000"`
+	Weight      *units.Decimal `json:"weight" binding:"omitempty,gte=0,max_load=2000,excluded_with=Percent1RM" unit:"load"`
+	Percent1RM  *float32       `json:"percent_1rm" binding:"omitempty,gt=0,max=150"`
+	RPE         *float32       `json:"rpe" binding:"omitempty,min=1,max=10,excluded_with=RIR"`
+	RIR         *int64         `json:"rir" binding:"omitempty,gte=0,max=10"`
+	RestSeconds int64          `json:"rest_seconds" binding:"gte=0,max=3600"`
+	Cardio
+}
+
+// Progression is the rule that moves the load of a plan exercise after each
+// finished session following the plan: linear adds increment when every
+// target was hit, double when every set reached the top of its rep range,
+// and rpe when the logged RPE is a point or more under target_rpe, taking it
+// off when a point or more over. After deload_after missed sessions in a row
+// the load drops by deload_percent, 10 by default. The increment is in the
+// units of the request.
+type Progression struct {
+	Type          string        `json:"type" binding:"required,oneof=linear double rpe" example:"linear"`
+	Increment     units.Decimal `json:"increment" binding:"required,gt=0,max_load=100" unit:"load"`
+	DeloadAfter   int64         `json:"deload_after" binding:"gte=0,max=20"`
+	DeloadPercent float32       `json:"deload_percent" binding:"gte=0,max=50"`
+	TargetRPE     float32       `json:"target_rpe" binding:"required_if=Type rpe,omitempty,min=1,max=10"`
+}
+
+// NewWorkoutPlanExercise adds an exercise to a plan at position, or after
+// the last exercise when position is 0 or past the end.
+type NewWorkoutPlanExercise struct {
+	WorkoutPlanExercise
+	Position int64 `json:"position" binding:"gte=0"`
+}
+
+// WorkoutPlanExerciseOrder lists the ids of every exercise of a plan in
+// their new order.
+type WorkoutPlanExerciseOrder struct {
+	IDs []uint `json:"ids" binding:"required,min=1,dive,gt=0"`
+}
+
+// @Tags Workout
+// @Summary Add an exercise to a workout plan
+// @Description Add an exercise with its targets to a workout plan of the authenticated user. The exercises at and after position move down by one; position cannot fall between the exercises of a group. Send the plan's ETag in If-Match to only add it if nobody changed the plan since.
+// @Param id path int true "Workout ID"
+// @Param If-Match header string false "ETag of the plan"
+// @Param exercise body NewWorkoutPlanExercise true "Exercise"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 201 {object} model.WorkoutPlan
+// @Header 201 {string} ETag "New version of the plan"
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/{id}/exercises [post]
+func AddWorkoutExercise(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	workout, lookupErr := findWorkout(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := etag.IfMatch(c, workout.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	if len(workout.Exercises) >= maxPlanExercises {
+		apperror.Abort(c, apperror.Conflict("too_many_exercises", "A workout plan can have at most "+strconv.Itoa(maxPlanExercises)+" exercises"))
+		return
+	}
+
+	var input NewWorkoutPlanExercise
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	units.ToSI(system, &input)
+	if err := checkPlanExercisesMeasures(db, noPath, input.WorkoutPlanExercise); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	position := input.Position
+	if position == 0 || position > int64(len(workout.Exercises))+1 {
+		position = int64(len(workout.Exercises)) + 1
+	}
+	ordered := append([]model.WorkoutPlanExercise(nil), workout.Exercises[:position-1]...)
+	ordered = append(append(ordered, model.WorkoutPlanExercise{}), workout.Exercises[position-1:]...)
+	if splitsGroup(ordered) {
+		apperror.Abort(c, apperror.Validation(apperror.FieldError{
+			Field:   "position",
+			Code:    "splits_group",
+			Message: "must not fall between the exercises of a group",
+		}))
+		return
+	}
+
+	changeErr := changeWorkoutExercises(db, &workout, c.GetHeader("If-Match"), func(tx *gorm.DB) *apperror.Error {
+		if err := tx.Model(&model.WorkoutPlanExercise{}).Where("workout_plan_id = ? AND position >= ?", workout.ID, position).
+			UpdateColumn("position", gorm.Expr("position + 1")).Error; err != nil {
+			return apperror.Database(err, "Failed to add exercise")
+		}
+		exercise := newPlanExercise(input.WorkoutPlanExercise, position)
+		exercise.WorkoutPlanId = int64(workout.ID)
+		if err := tx.Create(&exercise).Error; err != nil {
+			return apperror.Database(err, "Failed to add exercise")
+		}
+		return nil
+	})
+	if changeErr != nil {
+		apperror.Abort(c, changeErr)
+		return
+	}
+	etag.Set(c, workout.Version)
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusCreated, gin.H{"message": "Exercise added to workout plan", "data": workout})
+}
+
+// @Tags Workout
+// @Summary Update an exercise of a workout plan
+// @Description Change the targets of an exercise of a workout plan of the authenticated user, including its set prescriptions, which are replaced as a whole, with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json. The sets, repetitions and weight of an exercise with prescriptions are worked out from them, so a patch that changes them together with prescriptions fails with 422. Send the plan's ETag in If-Match to only apply the patch if nobody changed the plan since.
+// @Param id path int true "Workout ID"
+// @Param entry_id path int true "ID of the exercise in the plan"
+// @Param If-Match header string false "ETag of the plan"
+// @Param exercise body WorkoutPlanExercise true "Merge patch with the fields to change"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Success 200 {object} model.WorkoutPlan
+// @Header 200 {string} ETag "New version of the plan"
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 415 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/{id}/exercises/{entry_id} [patch]
+func UpdateWorkoutExercise(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	workout, lookupErr := findWorkout(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := etag.IfMatch(c, workout.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	exercise, lookupErr := findPlanExercise(workout, c.Param("entry_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+
+	stored, shown := editablePlanExercise(exercise), editablePlanExercise(exercise)
+	units.FromSI(system, &shown)
+	input := shown
+	if err := validation.BindPatch(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	if err := checkPrescribedSummary(input, shown); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	units.MergeSI(system, &input, &shown, &stored)
+	if err := checkPlanExercisesMeasures(db, noPath, input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	changeErr := changeWorkoutExercises(db, &workout, c.GetHeader("If-Match"), func(tx *gorm.DB) *apperror.Error {
+		changed := newPlanExercise(input, exercise.Position)
+		updates := map[string]interface{}{
+			"exercise_id":  changed.ExerciseId,
+			"sets":         changed.Sets,
+			"repetitions":  changed.Repetitions,
+			"weight":       changed.Weight,
+			"rest_seconds": changed.RestSeconds,
+			"notes":        changed.Notes,
+		}
+		for column, value := range cardioColumns(changed.Cardio) {
+			updates[column] = value
+		}
+		if err := tx.Model(&exercise).Updates(updates).Error; err != nil {
+			return apperror.Database(err, "Failed to update exercise")
+		}
+		if err := tx.Where("workout_plan_exercise_id = ?", exercise.ID).Delete(&model.WorkoutPlanSet{}).Error; err != nil {
+			return apperror.Database(err, "Failed to update exercise")
+		}
+		for _, set := range changed.Prescriptions {
+			set.WorkoutPlanExerciseId = int64(exercise.ID)
+			if err := tx.Create(&set).Error; err != nil {
+				return apperror.Database(err, "Failed to update exercise")
+			}
+		}
+		if err := tx.Where("workout_plan_exercise_id = ?", exercise.ID).Delete(&model.WorkoutPlanProgression{}).Error; err != nil {
+			return apperror.Database(err, "Failed to update exercise")
+		}
+		if changed.Progression == nil {
+			return nil
+		}
+		// A rule of the same type keeps counting the failures so far.
+		if exercise.Progression != nil && exercise.Progression.Type == changed.Progression.Type {
+			changed.Progression.Failures = exercise.Progression.Failures
+		}
+		changed.Progression.WorkoutPlanExerciseId = int64(exercise.ID)
+		if err := tx.Create(changed.Progression).Error; err != nil {
+			return apperror.Database(err, "Failed to update exercise")
+		}
+		return nil
+	})
+	if changeErr != nil {
+		apperror.Abort(c, changeErr)
+		return
+	}
+	etag.Set(c, workout.Version)
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusOK, gin.H{"message": "Workout plan exercise updated successfully", "data": workout})
+}
+
+// @Tags Workout
+// @Summary Reorder the exercises of a workout plan
+// @Description Put the exercises of a workout plan of the authenticated user in a new order. ids must list the id of every exercise of the plan exactly once, keeping the exercises of each group together. Send the plan's ETag in If-Match to only reorder them if nobody changed the plan since.
+// @Param id path int true "Workout ID"
+// @Param If-Match header string false "ETag of the plan"
+// @Param order body WorkoutPlanExerciseOrder true "Exercise ids in their new order"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.WorkoutPlan
+// @Header 200 {string} ETag "New version of the plan"
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/{id}/exercises/order [put]
+func ReorderWorkoutExercises(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	workout, lookupErr := findWorkout(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := etag.IfMatch(c, workout.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	var input WorkoutPlanExerciseOrder
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	if !isPermutation(workout.Exercises, input.IDs) {
+		apperror.Abort(c, apperror.Validation(apperror.FieldError{
+			Field:   "ids",
+			Code:    "permutation",
+			Message: "must list every exercise of the plan exactly once",
+		}))
+		return
+	}
+	if splitsGroup(reordered(workout.Exercises, input.IDs)) {
+		apperror.Abort(c, apperror.Validation(apperror.FieldError{
+			Field:   "ids",
+			Code:    "splits_group",
+			Message: "must keep the exercises of each group together",
+		}))
+		return
+	}
+
+	changeErr := changeWorkoutExercises(db, &workout, c.GetHeader("If-Match"), func(tx *gorm.DB) *apperror.Error {
+		for i, id := range input.IDs {
+			if err := tx.Model(&model.WorkoutPlanExercise{}).Where("id = ?", id).
+				UpdateColumn("position", i+1).Error; err != nil {
+				return apperror.Database(err, "Failed to reorder exercises")
+			}
+		}
+		return nil
+	})
+	if changeErr != nil {
+		apperror.Abort(c, changeErr)
+		return
+	}
+	etag.Set(c, workout.Version)
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusOK, gin.H{"message": "Workout plan exercises reordered successfully", "data": workout})
+}
+
+// @Tags Workout
+// @Summary Remove an exercise from a workout plan
+// @Description Remove an exercise from a workout plan of the authenticated user. The exercises after it move up by one; the last exercise of a plan cannot be removed. A group left with too few exercises for its type is ungrouped. Send the plan's ETag in If-Match to only remove it if nobody changed the plan since.
+// @Param id path int true "Workout ID"
+// @Param entry_id path int true "ID of the exercise in the plan"
+// @Param If-Match header string false "ETag of the plan"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.WorkoutPlan
+// @Header 200 {string} ETag "New version of the plan"
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/{id}/exercises/{entry_id} [delete]
+func RemoveWorkoutExercise(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	workout, lookupErr := findWorkout(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := etag.IfMatch(c, workout.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	exercise, lookupErr := findPlanExercise(workout, c.Param("entry_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if len(workout.Exercises) == 1 {
+		apperror.Abort(c, apperror.Conflict("last_workout_exercise", "A workout plan needs at least one exercise"))
+		return
+	}
+
+	changeErr := changeWorkoutExercises(db, &workout, c.GetHeader("If-Match"), func(tx *gorm.DB) *apperror.Error {
+		if err := tx.Where("workout_plan_exercise_id = ?", exercise.ID).Delete(&model.WorkoutPlanSet{}).Error; err != nil {
+			return apperror.Database(err, "Failed to remove exercise")
+		}
+		if err := tx.Where("workout_plan_exercise_id = ?", exercise.ID).Delete(&model.WorkoutPlanProgression{}).Error; err != nil {
+			return apperror.Database(err, "Failed to remove exercise")
+		}
+		if err := tx.Delete(&exercise).Error; err != nil {
+			return apperror.Database(err, "Failed to remove exercise")
+		}
+		if err := tx.Model(&model.WorkoutPlanExercise{}).Where("workout_plan_id = ? AND position > ?", workout.ID, exercise.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error; err != nil {
+			return apperror.Database(err, "Failed to remove exercise")
+		}
+		if exercise.GroupId == nil {
+			return nil
+		}
+		group, _ := findGroup(workout, strconv.FormatInt(*exercise.GroupId, 10))
+		if int64(len(groupPositions(workout, group.ID))-1) < minGroupSize(group.Type) {
+			return deleteGroup(tx, group)
+		}
+		return nil
+	})
+	if changeErr != nil {
+		apperror.Abort(c, changeErr)
+		return
+	}
+	etag.Set(c, workout.Version)
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusOK, gin.H{"message": "Exercise removed from workout plan", "data": workout})
+}
+
+func newPlanExercise(input WorkoutPlanExercise, position int64) model.WorkoutPlanExercise {
+	exercise := model.WorkoutPlanExercise{
+		ExerciseId:  input.ExerciseId,
+		Position:    position,
+		Sets:        input.Sets,
+		Repetitions: input.Repetitions,
+		Weight:      input.Weight,
+		RestSeconds: input.RestSeconds,
+		Notes:       input.Notes,
+		Cardio:      newCardio(input.Cardio),
+	}
+	if input.Progression != nil {
+		exercise.Progression = &model.WorkoutPlanProgression{
+			Type:          input.Progression.Type,
+			Increment:     input.Progression.Increment,
+			DeloadAfter:   input.Progression.DeloadAfter,
+			DeloadPercent: input.Progression.DeloadPercent,
+			TargetRPE:     input.Progression.TargetRPE,
+		}
+	}
+	if len(input.Prescriptions) == 0 {
+		return exercise
+	}
+
+	var reps, targets int64
+	exercise.Weight = 0
+	for i, set := range input.Prescriptions {
+		exercise.Prescriptions = append(exercise.Prescriptions, model.WorkoutPlanSet{
+			Position:    int64(i + 1),
+			Type:        set.Type,
+			Reps:        set.Reps,
+			MaxReps:     set.MaxReps,
+			Weight:      set.Weight,
+			Percent1RM:  set.Percent1RM,
+			RPE:         set.RPE,
+			RIR:         set.RIR,
+			RestSeconds: set.RestSeconds,
+			Cardio:      newCardio(set.Cardio),
+		})
+		if set.Reps > 0 {
+			reps += set.Reps
+			targets++
+		}
+		if set.Weight != nil && *set.Weight > exercise.Weight {
+			exercise.Weight = *set.Weight
+		}
+	}
+	exercise.Sets = int64(len(input.Prescriptions))
+	exercise.Repetitions = 0
+	if targets > 0 {
+		exercise.Repetitions = int64(math.Round(float64(reps) / float64(targets)))
+	}
+	return exercise
+}
+
+// editablePlanExercise returns the fields of exercise a client may change.
+func editablePlanExercise(exercise model.WorkoutPlanExercise) WorkoutPlanExercise {
+	input := WorkoutPlanExercise{
+		ExerciseId:  exercise.ExerciseId,
+		Sets:        exercise.Sets,
+		Repetitions: exercise.Repetitions,
+		Weight:      exercise.Weight,
+		RestSeconds: exercise.RestSeconds,
+		Notes:       exercise.Notes,
+		Cardio:      editableCardio(exercise.Cardio),
+	}
+	if progression := exercise.Progression; progression != nil {
+		input.Progression = &Progression{
+			Type:          progression.Type,
+			Increment:     progression.Increment,
+			DeloadAfter:   progression.DeloadAfter,
+			DeloadPercent: progression.DeloadPercent,
+			TargetRPE:     progression.TargetRPE,
+		}
+	}
+	for _, set := range exercise.Prescriptions {
+		input.Prescriptions = append(input.Prescriptions, SetPrescription{
+			Type:        set.Type,
+			Reps:        set.Reps,
+			MaxReps:     set.MaxReps,
+			Weight:      copyDecimal(set.Weight),
+			Percent1RM:  set.Percent1RM,
+			RPE:         set.RPE,
+			RIR:         set.RIR,
+			RestSeconds: set.RestSeconds,
+			Cardio:      editableCardio(set.Cardio),
+		})
+	}
+	return input
+}
+
+// checkPrescribedSummary rejects a patch that changes the sets, repetitions
+// or weight of an exercise with prescriptions, which are worked out from the
+// prescriptions and would be overwritten.
+func checkPrescribedSummary(input, shown WorkoutPlanExercise) *apperror.Error {
+	if len(input.Prescriptions) == 0 {
+		return nil
+	}
+	var fields []apperror.FieldError
+	summary := func(field string) {
+		fields = append(fields, apperror.FieldError{
+			Field:   field,
+			Code:    "excluded_with",
+			Message: "must not be changed together with prescriptions, which it is worked out from",
+		})
+	}
+	if input.Sets != shown.Sets {
+		summary("sets")
+	}
+	if input.Repetitions != shown.Repetitions {
+		summary("repetitions")
+	}
+	if input.Weight != shown.Weight {
+		summary("weight")
+	}
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
+
+// findPlanExercise returns the exercise of workout with the given id.
+func findPlanExercise(workout model.WorkoutPlan, id string) (model.WorkoutPlanExercise, *apperror.Error) {
+	entryId, err := strconv.ParseUint(id, 10, 64)
+	if err == nil {
+		for _, exercise := range workout.Exercises {
+			if uint64(exercise.ID) == entryId {
+				return exercise, nil
+			}
+		}
+	}
+	return model.WorkoutPlanExercise{}, apperror.NotFound("workout_exercise_not_found", "Exercise not found in this workout plan")
+}
+
+// reordered returns exercises in the order of ids.
+func reordered(exercises []model.WorkoutPlanExercise, ids []uint) []model.WorkoutPlanExercise {
+	byID := make(map[uint]model.WorkoutPlanExercise, len(exercises))
+	for _, exercise := range exercises {
+		byID[exercise.ID] = exercise
+	}
+	ordered := make([]model.WorkoutPlanExercise, len(ids))
+	for i, id := range ids {
+		ordered[i] = byID[id]
+	}
+	return ordered
+}
+
+// isPermutation reports whether ids holds the id of each of exercises once.
+func isPermutation(exercises []model.WorkoutPlanExercise, ids []uint) bool {
+	if len(ids) != len(exercises) {
+		return false
+	}
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	for _, exercise := range exercises {
+		if !seen[exercise.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+// changeWorkoutExercises runs change in a transaction that also bumps the
+// version of workout, failing if the plan changed since it was read.
+// workout is reloaded on success.
+func changeWorkoutExercises(db *gorm.DB, workout *model.WorkoutPlan, ifMatch string, change func(tx *gorm.DB) *apperror.Error) *apperror.Error {
+	tx := db.Begin()
+	result := tx.Model(&model.WorkoutPlan{}).Where("id = ? AND version = ?", workout.ID, workout.Version).
+		Updates(map[string]interface{}{"version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		tx.Rollback()
+		return apperror.Database(result.Error, "Failed to update workout plan")
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return etag.Stale(ifMatch)
+	}
+	if err := change(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := withExercises(tx).First(workout, workout.ID).Error; err != nil {
+		tx.Rollback()
+		return apperror.Database(err, "Failed to retrieve updated workout plan")
+	}
+	if err := tx.Commit().Error; err != nil {
+		return apperror.Database(err, "Failed to update workout plan")
+	}
+	return nil
+}
@@ -0,0 +1,415 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"workout_tracker/internal/config"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/etag"
+	"workout_tracker/pkg/units"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+// WorkoutPlanGroup groups adjacent exercises of a plan, listed by their
+// positions. rest_seconds is the rest after each round; the exercises of a
+// group follow each other without their own rest. rounds only applies to
+// circuits, as supersets and giant sets run one round per set.
+type WorkoutPlanGroup struct {
+	Type        string  `json:"type" binding:"required,oneof=superset circuit giant_set" example:"superset"`
+	Rounds      int64   `json:"rounds" binding:"required_if=Type circuit,gte=0,max=50"`
+	RestSeconds int64   `json:"rest_seconds" binding:"gte=0,max=3600"`
+	Exercises   []int64 `json:"exercises" binding:"required,min=2,max=20,unique,dive,min=1"`
+}
+
+// groupSpan is a group of a plan being checked. field names it in errors;
+// groups that already exist have none.
+type groupSpan struct {
+	field     string
+	kind      string
+	positions []int64
+}
+
+// @Tags Workout
+// @Summary Group exercises of a workout plan
+// @Description Make a superset, circuit or giant set of adjacent exercises of a workout plan of the authenticated user. A superset has exactly 2 exercises, a giant set at least 3 and a circuit at least 2, none of which may already be in a group. Send the plan's ETag in If-Match to only group them if nobody changed the plan since.
+// @Param id path int true "Workout ID"
+// @Param If-Match header string false "ETag of the plan"
+// @Param group body WorkoutPlanGroup true "Group"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 201 {object} model.WorkoutPlan
+// @Header 201 {string} ETag "New version of the plan"
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/{id}/groups [post]
+func AddWorkoutGroup(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	workout, lookupErr := findWorkout(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := etag.IfMatch(c, workout.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	var input WorkoutPlanGroup
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	spans := append(existingGroupSpans(workout, 0), groupSpan{field: "exercises", kind: input.Type, positions: input.Exercises})
+	if fields := checkGroupSpans(spans, int64(len(workout.Exercises))); len(fields) > 0 {
+		apperror.Abort(c, apperror.Validation(fields...))
+		return
+	}
+
+	changeErr := changeWorkoutExercises(db, &workout, c.GetHeader("If-Match"), func(tx *gorm.DB) *apperror.Error {
+		return createGroup(tx, workout.ID, input)
+	})
+	if changeErr != nil {
+		apperror.Abort(c, changeErr)
+		return
+	}
+	etag.Set(c, workout.Version)
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusCreated, gin.H{"message": "Exercise group added to workout plan", "data": workout})
+}
+
+// @Tags Workout
+// @Summary Update an exercise group of a workout plan
+// @Description Change the type, rounds, rest or exercises of a group of a workout plan of the authenticated user with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json. Send the plan's ETag in If-Match to only apply the patch if nobody changed the plan since.
+// @Param id path int true "Workout ID"
+// @Param group_id path int true "Group ID"
+// @Param If-Match header string false "ETag of the plan"
+// @Param group body WorkoutPlanGroup true "Merge patch with the fields to change"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Success 200 {object} model.WorkoutPlan
+// @Header 200 {string} ETag "New version of the plan"
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 415 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/{id}/groups/{group_id} [patch]
+func UpdateWorkoutGroup(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	workout, lookupErr := findWorkout(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := etag.IfMatch(c, workout.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	group, lookupErr := findGroup(workout, c.Param("group_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+
+	input := editableGroup(workout, group)
+	if err := validation.BindPatch(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	spans := append(existingGroupSpans(workout, group.ID), groupSpan{field: "exercises", kind: input.Type, positions: input.Exercises})
+	if fields := checkGroupSpans(spans, int64(len(workout.Exercises))); len(fields) > 0 {
+		apperror.Abort(c, apperror.Validation(fields...))
+		return
+	}
+
+	changeErr := changeWorkoutExercises(db, &workout, c.GetHeader("If-Match"), func(tx *gorm.DB) *apperror.Error {
+		updates := map[string]interface{}{
+			"type":         input.Type,
+			"rounds":       groupRounds(input),
+			"rest_seconds": input.RestSeconds,
+		}
+		if err := tx.Model(&group).Updates(updates).Error; err != nil {
+			return apperror.Database(err, "Failed to update exercise group")
+		}
+		return setGroupMembers(tx, workout.ID, group.ID, input.Exercises)
+	})
+	if changeErr != nil {
+		apperror.Abort(c, changeErr)
+		return
+	}
+	etag.Set(c, workout.Version)
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusOK, gin.H{"message": "Exercise group updated successfully", "data": workout})
+}
+
+// @Tags Workout
+// @Summary Remove an exercise group from a workout plan
+// @Description Ungroup the exercises of a group of a workout plan of the authenticated user. The exercises stay in the plan. Send the plan's ETag in If-Match to only ungroup them if nobody changed the plan since.
+// @Param id path int true "Workout ID"
+// @Param group_id path int true "Group ID"
+// @Param If-Match header string false "ETag of the plan"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.WorkoutPlan
+// @Header 200 {string} ETag "New version of the plan"
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/{id}/groups/{group_id} [delete]
+func RemoveWorkoutGroup(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	workout, lookupErr := findWorkout(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := etag.IfMatch(c, workout.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	group, lookupErr := findGroup(workout, c.Param("group_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+
+	changeErr := changeWorkoutExercises(db, &workout, c.GetHeader("If-Match"), func(tx *gorm.DB) *apperror.Error {
+		return deleteGroup(tx, group)
+	})
+	if changeErr != nil {
+		apperror.Abort(c, changeErr)
+		return
+	}
+	etag.Set(c, workout.Version)
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusOK, gin.H{"message": "Exercise group removed from workout plan", "data": workout})
+}
+
+// createGroup adds a group to the plan with the given id and moves the
+// exercises at its positions into it.
+func createGroup(db *gorm.DB, workoutId uint, input WorkoutPlanGroup) *apperror.Error {
+	group := model.WorkoutPlanGroup{
+		WorkoutPlanId: int64(workoutId),
+		Type:          input.Type,
+		Rounds:        groupRounds(input),
+		RestSeconds:   input.RestSeconds,
+	}
+	if err := db.Create(&group).Error; err != nil {
+		return apperror.Database(err, "Failed to create exercise group")
+	}
+	return setGroupMembers(db, workoutId, group.ID, input.Exercises)
+}
+
+// setGroupMembers makes the exercises at positions the only members of the
+// group.
+func setGroupMembers(db *gorm.DB, workoutId, groupId uint, positions []int64) *apperror.Error {
+	if err := db.Model(&model.WorkoutPlanExercise{}).Where("group_id = ?", groupId).
+		UpdateColumn("group_id", nil).Error; err != nil {
+		return apperror.Database(err, "Failed to update exercise group")
+	}
+	if err := db.Model(&model.WorkoutPlanExercise{}).Where("workout_plan_id = ? AND position IN (?)", workoutId, positions).
+		UpdateColumn("group_id", groupId).Error; err != nil {
+		return apperror.Database(err, "Failed to update exercise group")
+	}
+	return nil
+}
+
+// deleteGroup removes group and leaves its exercises ungrouped.
+func deleteGroup(db *gorm.DB, group model.WorkoutPlanGroup) *apperror.Error {
+	if err := db.Model(&model.WorkoutPlanExercise{}).Where("group_id = ?", group.ID).
+		UpdateColumn("group_id", nil).Error; err != nil {
+		return apperror.Database(err, "Failed to remove exercise group")
+	}
+	if err := db.Delete(&group).Error; err != nil {
+		return apperror.Database(err, "Failed to remove exercise group")
+	}
+	return nil
+}
+
+// minGroupSize is the fewest exercises a group of the given type can have.
+func minGroupSize(kind string) int64 {
+	switch kind {
+	case model.GroupSuperset:
+		return 2
+	case model.GroupGiantSet:
+		return 3
+	}
+	return 2
+}
+
+func groupRounds(input WorkoutPlanGroup) int64 {
+	if input.Type != model.GroupCircuit {
+		return 0
+	}
+	return input.Rounds
+}
+
+// editableGroup returns the fields of group a client may change.
+func editableGroup(workout model.WorkoutPlan, group model.WorkoutPlanGroup) WorkoutPlanGroup {
+	return WorkoutPlanGroup{
+		Type:        group.Type,
+		Rounds:      group.Rounds,
+		RestSeconds: group.RestSeconds,
+		Exercises:   groupPositions(workout, group.ID),
+	}
+}
+
+// findGroup returns the group of workout with the given id.
+func findGroup(workout model.WorkoutPlan, id string) (model.WorkoutPlanGroup, *apperror.Error) {
+	groupId, err := strconv.ParseUint(id, 10, 64)
+	if err == nil {
+		for _, group := range workout.Groups {
+			if uint64(group.ID) == groupId {
+				return group, nil
+			}
+		}
+	}
+	return model.WorkoutPlanGroup{}, apperror.NotFound("workout_group_not_found", "Exercise group not found in this workout plan")
+}
+
+// groupPositions returns the positions of the exercises of workout in the
+// group with the given id.
+func groupPositions(workout model.WorkoutPlan, groupId uint) []int64 {
+	var positions []int64
+	for _, exercise := range workout.Exercises {
+		if exercise.GroupId != nil && uint(*exercise.GroupId) == groupId {
+			positions = append(positions, exercise.Position)
+		}
+	}
+	return positions
+}
+
+// existingGroupSpans returns the groups of workout, except the one with id
+// skip, for checking a new or changed group against.
+func existingGroupSpans(workout model.WorkoutPlan, skip uint) []groupSpan {
+	var spans []groupSpan
+	for _, group := range workout.Groups {
+		if group.ID != skip {
+			spans = append(spans, groupSpan{kind: group.Type, positions: groupPositions(workout, group.ID)})
+		}
+	}
+	return spans
+}
+
+// checkGroupSpans reports the groups that do not list adjacent positions of
+// a plan with the given number of exercises, have the wrong number of
+// exercises for their type, or share an exercise with another group.
+func checkGroupSpans(spans []groupSpan, exercises int64) []apperror.FieldError {
+	var fields []apperror.FieldError
+	invalid := func(span groupSpan, code, message string) {
+		fields = append(fields, apperror.FieldError{Field: span.field, Code: code, Message: message})
+	}
+	owner := map[int64]int{}
+	for i, span := range spans {
+		positions := append([]int64(nil), span.positions...)
+		sort.Slice(positions, func(a, b int) bool { return positions[a] < positions[b] })
+
+		switch {
+		case hasRepeats(positions):
+			invalid(span, "not_adjacent", "must list adjacent exercises, each once")
+			continue
+		case positions[len(positions)-1] > exercises:
+			invalid(span, "out_of_range", fmt.Sprintf("must list positions from 1 to %d", exercises))
+			continue
+		case positions[len(positions)-1]-positions[0] != int64(len(positions)-1):
+			invalid(span, "not_adjacent", "must list adjacent exercises, each once")
+			continue
+		case span.kind == model.GroupSuperset && len(positions) != 2:
+			invalid(span, "group_size", "a superset must have exactly 2 exercises")
+		case int64(len(positions)) < minGroupSize(span.kind):
+			invalid(span, "group_size", fmt.Sprintf("a %s must have at least %d exercises", strings.ReplaceAll(span.kind, "_", " "), minGroupSize(span.kind)))
+		}
+
+		for _, position := range positions {
+			if other, ok := owner[position]; ok && other != i {
+				invalid(span, "overlaps", "must not share exercises with another group")
+				break
+			}
+			owner[position] = i
+		}
+	}
+	return fields
+}
+
+// hasRepeats reports whether sorted lists a position more than once.
+func hasRepeats(sorted []int64) bool {
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return true
+		}
+	}
+	return false
+}
+
+// splitsGroup reports whether exercises, in order, leave the members of a
+// group apart.
+func splitsGroup(exercises []model.WorkoutPlanExercise) bool {
+	closed := map[int64]bool{}
+	var current *int64
+	for _, exercise := range exercises {
+		if current != nil && (exercise.GroupId == nil || *exercise.GroupId != *current) {
+			closed[*current] = true
+		}
+		if exercise.GroupId != nil && closed[*exercise.GroupId] {
+			return true
+		}
+		current = exercise.GroupId
+	}
+	return false
+}
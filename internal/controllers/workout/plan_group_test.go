@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"testing"
+	model "workout_tracker/internal/model/workout"
+)
+
+func TestCheckGroupSpans(t *testing.T) {
+	span := func(kind string, positions ...int64) groupSpan {
+		return groupSpan{field: "groups[0].exercises", kind: kind, positions: positions}
+	}
+
+	tests := []struct {
+		name  string
+		spans []groupSpan
+		// want is the code of each error reported, in order.
+		want []string
+	}{
+		{"adjacent superset", []groupSpan{span(model.GroupSuperset, 2, 1)}, nil},
+		{"adjacent giant set", []groupSpan{span(model.GroupGiantSet, 2, 3, 4)}, nil},
+		{"circuits side by side", []groupSpan{span(model.GroupCircuit, 1, 2), span(model.GroupCircuit, 3, 4)}, nil},
+		{"gap in a circuit", []groupSpan{span(model.GroupCircuit, 1, 3)}, []string{"not_adjacent"}},
+		{"repeat around a gap", []groupSpan{span(model.GroupCircuit, 1, 1, 3)}, []string{"not_adjacent"}},
+		{"repeat filling a giant set", []groupSpan{span(model.GroupGiantSet, 1, 2, 2)}, []string{"not_adjacent"}},
+		{"repeat of a single exercise", []groupSpan{span(model.GroupSuperset, 2, 2)}, []string{"not_adjacent"}},
+		{"past the last exercise", []groupSpan{span(model.GroupSuperset, 4, 5)}, []string{"out_of_range"}},
+		{"superset of three", []groupSpan{span(model.GroupSuperset, 1, 2, 3)}, []string{"group_size"}},
+		{"giant set of two", []groupSpan{span(model.GroupGiantSet, 1, 2)}, []string{"group_size"}},
+		{"overlapping groups", []groupSpan{span(model.GroupSuperset, 1, 2), span(model.GroupSuperset, 2, 3)}, []string{"overlaps"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := checkGroupSpans(tt.spans, 4)
+			var got []string
+			for _, field := range fields {
+				got = append(got, field.Code)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got errors %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got errors %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,669 @@
+package controllers
+
+import (
+	"math"
+	"net/http"
+	"time"
+	"workout_tracker/internal/config"
+	userModel "workout_tracker/internal/model/user"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/etag"
+	"workout_tracker/pkg/metrics"
+	"workout_tracker/pkg/pagination"
+	"workout_tracker/pkg/units"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+// Program is a training block. Its weeks are numbered in the order given,
+// and in every week the plans of its days are done in the order given, one
+// per training day, unless the week lists days of its own.
+type Program struct {
+	Name        string        `json:"name" binding:"required,max=255"`
+	Description string        `json:"description" binding:"max=1000"`
+	Weeks       []ProgramWeek `json:"weeks" binding:"required,min=1,max=52,dive"`
+	Days        []ProgramDay  `json:"days" binding:"required,min=1,max=7,dive"`
+}
+
+// ProgramWeek scales the loads and the sets of the week's workouts to a
+// percentage of what their plans prescribe. Percentages left out are 100.
+// Days, when given, are done that week instead of the days of the program.
+type ProgramWeek struct {
+	IntensityPercent float64      `json:"intensity_percent" binding:"omitempty,gt=0,max=200" example:"100"`
+	VolumePercent    float64      `json:"volume_percent" binding:"omitempty,gt=0,max=200" example:"100"`
+	Notes            string       `json:"notes" binding:"max=1000"`
+	Days             []ProgramDay `json:"days" binding:"omitempty,max=7,dive"`
+}
+
+type ProgramDay struct {
+	WorkoutPlanId int64 `json:"workout_plan_id" binding:"required,gt=0,workout_plan"`
+}
+
+// Enrollment starts a program on StartDate. Its workouts are scheduled on
+// TrainingDays, or on the user's preferred training days when left out.
+type Enrollment struct {
+	StartDate    string   `json:"start_date" binding:"required,datetime=2006-01-02" example:"2026-01-05"`
+	TrainingDays []string `json:"training_days" binding:"omitempty,min=1,max=7,unique,dive,oneof=mon tue wed thu fri sat sun" example:"mon,wed,fri"`
+}
+
+var programSorts = pagination.Sortable{"created_at": "created_at", "name": "name"}
+
+// @Tags Workout
+// @Summary Get user training programs
+// @Description Get a page of the training programs of the authenticated user
+// @Param limit query int false "Page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "created_at or name, prefixed with - for descending" default(created_at)
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.Program
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /programs [get]
+func GetMyPrograms(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	var query pagination.Params
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	page, pageErr := pagination.New(query, programSorts, "created_at")
+	if pageErr != nil {
+		apperror.Abort(c, pageErr)
+		return
+	}
+
+	db := withWeeksAndDays(config.GetDBContext(c.Request.Context())).Where("user_id = ?", userId)
+	programs, meta, err := pagination.Find[model.Program](db, page)
+	if err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to retrieve programs"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Programs retrieved successfully", "data": programs, "pagination": meta})
+}
+
+// @Tags Workout
+// @Summary Get user training program by id
+// @Description Get the training program of the authenticated user by id, with its weeks and days. Send the ETag of a previous response in If-None-Match to get 304 Not Modified while the program is unchanged.
+// @Param id path int true "Program ID"
+// @Param If-None-Match header string false "ETag of the cached program"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.Program
+// @Header 200 {string} ETag "Version of the program"
+// @Success 304
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /programs/{id} [get]
+func GetProgramByID(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	program, lookupErr := findProgram(config.GetDBContext(c.Request.Context()), userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if etag.NotModified(c, program.Version) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Program retrieved successfully", "data": program})
+}
+
+// @Tags Workout
+// @Summary Create user training program
+// @Description Create a training program for the authenticated user from weeks, which may scale the loads and sets of their workouts, and days, each naming the workout plan done on that training day of every week. A week may list days of its own, done that week instead. A 12-week program of 4 days with a deload in week 4 has 12 weeks, the fourth with lower percentages, and 4 days; one alternating two sets of plans has the second set as the days of every other week.
+// @Param program body Program true "Program"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Accept json
+// @Produce json
+// @Success 201 {object} model.Program
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /programs [post]
+func CreateProgram(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	c.Set(validation.UserIDKey, userId)
+	var input Program
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	program := newProgram(userId, input)
+	tx := config.GetDBContext(c.Request.Context()).Begin()
+	if err := tx.Create(&program).Error; err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to create program"))
+		return
+	}
+	if err := createWeekDays(tx, program.ID, program.Weeks); err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to create program"))
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to create program"))
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Program created successfully", "data": program})
+}
+
+// @Tags Workout
+// @Summary Update user training program
+// @Description Update a training program of the authenticated user with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json. A merge patch replaces weeks and days as a whole. Workouts already scheduled by enrolling in the program are left as they are. Send the program's ETag in If-Match to only apply the patch if nobody changed the program since.
+// @Param id path int true "Program ID"
+// @Param If-Match header string false "ETag of the program being patched"
+// @Param program body Program true "Merge patch with the fields to change"
+// @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Success 202 {object} model.Program
+// @Header 202 {string} ETag "New version of the program"
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 415 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /programs/{id} [patch]
+func UpdateProgram(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	program, lookupErr := findProgram(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := etag.IfMatch(c, program.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.Set(validation.UserIDKey, userId)
+	input := editableProgram(program)
+	if err := validation.BindPatch(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	tx := db.Begin()
+	if err := saveProgram(tx, &program, input, c.GetHeader("If-Match")); err != nil {
+		tx.Rollback()
+		apperror.Abort(c, err)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to update program"))
+		return
+	}
+	etag.Set(c, program.Version)
+	c.JSON(http.StatusAccepted, gin.H{"message": "Program updated successfully", "data": program})
+}
+
+// @Tags Workout
+// @Summary Delete user training program by id
+// @Description Delete a training program of the authenticated user. Workouts already scheduled by enrolling in the program are kept. Send the program's ETag in If-Match to only delete it if nobody changed it since.
+// @Param id path int true "Program ID"
+// @Param If-Match header string false "ETag of the program being deleted"
+// @Accept json
+// @Produce json
+// @Success 204 {object} map[string]string
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /programs/{id} [delete]
+func DeleteProgram(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	program, lookupErr := findProgram(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	if err := etag.IfMatch(c, program.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	result := db.Where("version = ?", program.Version).Delete(&program)
+	if result.Error != nil {
+		apperror.Abort(c, apperror.Database(result.Error, "Failed to delete program"))
+		return
+	}
+	if result.RowsAffected == 0 {
+		apperror.Abort(c, etag.Stale(c.GetHeader("If-Match")))
+		return
+	}
+	c.JSON(http.StatusNoContent, gin.H{"message": "Program deleted"})
+}
+
+// @Tags Workout
+// @Summary Enroll in a training program
+// @Description Start a training program of the authenticated user on start_date by scheduling its workouts. Week n of the program covers the 7 days from start_date plus n-1 weeks, and its days are scheduled in order on the first of those dates that fall on a training day. Training days are those of the request, or the user's preferred training days, and must be at least as many as the days of the busiest week of the program. Each schedule records its program week, whose percentages scale its loads and sets when it is retrieved.
+// @Param id path int true "Program ID"
+// @Param enrollment body Enrollment true "Enrollment"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Accept json
+// @Produce json
+// @Success 201 {object} model.ProgramEnrollment
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /programs/{id}/enroll [post]
+func EnrollInProgram(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	var input Enrollment
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	db := config.GetDBContext(c.Request.Context())
+	program, lookupErr := findProgram(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+
+	days := userModel.Weekdays(input.TrainingDays)
+	if days == nil {
+		var user userModel.User
+		if err := db.Select("training_days").First(&user, userId).Error; err != nil {
+			apperror.Abort(c, apperror.Lookup(err, "user_not_found", "User not found"))
+			return
+		}
+		days = user.TrainingDays
+	}
+	if len(days) < maxWeekDays(program) {
+		apperror.Abort(c, apperror.Validation(apperror.FieldError{
+			Field:   "training_days",
+			Code:    "min",
+			Message: "must contain at least as many days as the program has, or be left out to use your preferred training days",
+		}))
+		return
+	}
+	if err := checkProgramPlans(db, program); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	startDate, _ := time.Parse("2006-01-02", input.StartDate)
+	enrollment := model.ProgramEnrollment{
+		UserId:       userId,
+		ProgramId:    int64(program.ID),
+		StartDate:    startDate,
+		TrainingDays: days,
+	}
+	tx := db.Begin()
+	if err := tx.Create(&enrollment).Error; err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to enroll in program"))
+		return
+	}
+	enrollment.Schedules = programSchedules(program, enrollment)
+	for i := range enrollment.Schedules {
+		if err := tx.Create(&enrollment.Schedules[i]).Error; err != nil {
+			tx.Rollback()
+			apperror.Abort(c, apperror.Database(err, "Failed to schedule program"))
+			return
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to enroll in program"))
+		return
+	}
+	metrics.ProgramEnrollments.Inc()
+	metrics.SchedulesCreated.Add(float64(len(enrollment.Schedules)))
+	c.JSON(http.StatusCreated, gin.H{"message": "Enrolled in program successfully", "data": enrollment})
+}
+
+// findProgram loads one of the user's programs with its weeks and days.
+func findProgram(db *gorm.DB, userId int64, id interface{}) (model.Program, *apperror.Error) {
+	var program model.Program
+	if err := withWeeksAndDays(db).First(&program, map[string]interface{}{"id": id, "user_id": userId}).Error; err != nil {
+		return program, apperror.Lookup(err, "program_not_found", "Program not found")
+	}
+	return program, nil
+}
+
+// withWeeksAndDays makes db load the weeks and days of programs in order,
+// the days of single weeks with their week only.
+func withWeeksAndDays(db *gorm.DB) *gorm.DB {
+	byPosition := func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}
+	return db.Preload("Weeks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("number")
+	}).Preload("Weeks.Days", byPosition).Preload("Days", func(db *gorm.DB) *gorm.DB {
+		return byPosition(db.Where("program_week_id IS NULL"))
+	})
+}
+
+func newProgram(userId int64, input Program) model.Program {
+	program := model.Program{
+		UserId:      userId,
+		Name:        input.Name,
+		Description: input.Description,
+	}
+	program.Weeks, program.Days = programWeeksAndDays(input)
+	return program
+}
+
+// programWeeksAndDays numbers the weeks and days of input in order, filling
+// in the percentages left out. The days of the weeks are left for
+// createWeekDays to save.
+func programWeeksAndDays(input Program) ([]model.ProgramWeek, []model.ProgramDay) {
+	percent := func(p float64) float64 {
+		if p == 0 {
+			return 100
+		}
+		return p
+	}
+	weeks := make([]model.ProgramWeek, len(input.Weeks))
+	for i, week := range input.Weeks {
+		weeks[i] = model.ProgramWeek{
+			Number:           int64(i + 1),
+			IntensityPercent: percent(week.IntensityPercent),
+			VolumePercent:    percent(week.VolumePercent),
+			Notes:            week.Notes,
+			Days:             programDays(week.Days),
+		}
+	}
+	return weeks, programDays(input.Days)
+}
+
+// programDays numbers days in order.
+func programDays(input []ProgramDay) []model.ProgramDay {
+	if len(input) == 0 {
+		return nil
+	}
+	days := make([]model.ProgramDay, len(input))
+	for i, day := range input {
+		days[i] = model.ProgramDay{Position: int64(i + 1), WorkoutPlanId: day.WorkoutPlanId}
+	}
+	return days
+}
+
+// createWeekDays saves the days of weeks, which have been saved as weeks of
+// the program with id programId. GORM leaves them out when saving the weeks
+// as they also belong to the program. db should be a transaction.
+func createWeekDays(db *gorm.DB, programId uint, weeks []model.ProgramWeek) error {
+	for i := range weeks {
+		weekId := int64(weeks[i].ID)
+		for j := range weeks[i].Days {
+			day := &weeks[i].Days[j]
+			day.ProgramId, day.ProgramWeekId = int64(programId), &weekId
+			if err := db.Create(day).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// editableProgram returns the fields of program a client may change.
+func editableProgram(program model.Program) Program {
+	input := Program{
+		Name:        program.Name,
+		Description: program.Description,
+		Weeks:       make([]ProgramWeek, len(program.Weeks)),
+		Days:        make([]ProgramDay, len(program.Days)),
+	}
+	for i, week := range program.Weeks {
+		input.Weeks[i] = ProgramWeek{IntensityPercent: week.IntensityPercent, VolumePercent: week.VolumePercent, Notes: week.Notes}
+		for _, day := range week.Days {
+			input.Weeks[i].Days = append(input.Weeks[i].Days, ProgramDay{WorkoutPlanId: day.WorkoutPlanId})
+		}
+	}
+	for i, day := range program.Days {
+		input.Days[i] = ProgramDay{WorkoutPlanId: day.WorkoutPlanId}
+	}
+	return input
+}
+
+// saveProgram writes input over program, replacing its weeks and days, and
+// bumps its version, failing if the program changed since it was read.
+// program is reloaded on success. db should be a transaction.
+func saveProgram(db *gorm.DB, program *model.Program, input Program, ifMatch string) *apperror.Error {
+	fail := func(err error) *apperror.Error {
+		return apperror.Database(err, "Failed to update program")
+	}
+	updates := map[string]interface{}{
+		"name":        input.Name,
+		"description": input.Description,
+		"version":     gorm.Expr("version + 1"),
+	}
+	result := db.Model(&model.Program{}).Where("id = ? AND version = ?", program.ID, program.Version).Updates(updates)
+	if result.Error != nil {
+		return fail(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return etag.Stale(ifMatch)
+	}
+	if err := db.Where("program_id = ?", program.ID).Delete(&model.ProgramWeek{}).Error; err != nil {
+		return fail(err)
+	}
+	if err := db.Where("program_id = ?", program.ID).Delete(&model.ProgramDay{}).Error; err != nil {
+		return fail(err)
+	}
+	weeks, days := programWeeksAndDays(input)
+	for i := range weeks {
+		weeks[i].ProgramId = int64(program.ID)
+		if err := db.Create(&weeks[i]).Error; err != nil {
+			return fail(err)
+		}
+	}
+	for i := range days {
+		days[i].ProgramId = int64(program.ID)
+		if err := db.Create(&days[i]).Error; err != nil {
+			return fail(err)
+		}
+	}
+	if err := createWeekDays(db, program.ID, weeks); err != nil {
+		return fail(err)
+	}
+	if err := withWeeksAndDays(db).First(program, program.ID).Error; err != nil {
+		return apperror.Database(err, "Failed to retrieve updated program")
+	}
+	return nil
+}
+
+// checkProgramPlans fails if a day of program, or of one of its weeks, is a
+// plan that has been deleted since the program was saved.
+func checkProgramPlans(db *gorm.DB, program model.Program) *apperror.Error {
+	var planIds []int64
+	for _, days := range weekDays(program) {
+		for _, day := range days {
+			planIds = append(planIds, day.WorkoutPlanId)
+		}
+	}
+	planIds = uniqueIds(planIds)
+	var count int
+	if err := db.Model(&model.WorkoutPlan{}).Where("id IN (?) AND user_id = ?", planIds, program.UserId).Count(&count).Error; err != nil {
+		return apperror.Database(err, "Failed to retrieve program plans")
+	}
+	if count < len(planIds) {
+		return apperror.Conflict("program_plan_deleted", "A day of the program is a workout plan that has been deleted")
+	}
+	return nil
+}
+
+// weekDays returns the days done in each week of program: those of the week,
+// or of the program when the week has none.
+func weekDays(program model.Program) [][]model.ProgramDay {
+	days := make([][]model.ProgramDay, len(program.Weeks))
+	for i, week := range program.Weeks {
+		days[i] = program.Days
+		if len(week.Days) > 0 {
+			days[i] = week.Days
+		}
+	}
+	return days
+}
+
+// maxWeekDays returns the number of training days of the busiest week of
+// program.
+func maxWeekDays(program model.Program) int {
+	most := 0
+	for _, days := range weekDays(program) {
+		most = max(most, len(days))
+	}
+	return most
+}
+
+// programSchedules lays out the workouts of program for enrollment: each
+// week, its days in order on the first training days of the week.
+func programSchedules(program model.Program, enrollment model.ProgramEnrollment) []model.WorkoutSchedule {
+	enrollmentId := int64(enrollment.ID)
+	days := weekDays(program)
+	var schedules []model.WorkoutSchedule
+	for w, week := range program.Weeks {
+		weekStart := enrollment.StartDate.AddDate(0, 0, 7*w)
+		day := 0
+		for d := 0; d < 7 && day < len(days[w]); d++ {
+			date := weekStart.AddDate(0, 0, d)
+			if !enrollment.TrainingDays.Has(date.Weekday()) {
+				continue
+			}
+			schedules = append(schedules, model.WorkoutSchedule{
+				UserId:              enrollment.UserId,
+				WorkoutPlanId:       days[w][day].WorkoutPlanId,
+				ScheduledDate:       date,
+				Status:              model.StatusScheduled,
+				ProgramEnrollmentId: &enrollmentId,
+				ProgramWeek:         week.Number,
+				IntensityPercent:    week.IntensityPercent,
+				VolumePercent:       week.VolumePercent,
+			})
+			day++
+		}
+	}
+	return schedules
+}
+
+// applyProgramWeek scales workout to the program week of schedule: its
+// absolute loads and percentages of the one-rep max by the week's intensity,
+// rounded to the plates of system, and the sets of its exercises by the
+// week's volume, keeping at least one. Working sets added to reach the
+// volume repeat the last working set and have no id.
+func applyProgramWeek(workout *model.WorkoutPlan, schedule model.WorkoutSchedule, system units.System) {
+	if !schedule.Modified() {
+		return
+	}
+	intensity, volume := schedule.IntensityPercent/100, schedule.VolumePercent/100
+	scale := func(weight units.Decimal) units.Decimal {
+		return units.Round(system, units.Load, units.NewDecimal(weight.Float64()*intensity), loadSteps[system])
+	}
+	for i := range workout.Exercises {
+		exercise := &workout.Exercises[i]
+		if intensity != 1 {
+			exercise.Weight = scale(exercise.Weight)
+			for j := range exercise.Prescriptions {
+				set := &exercise.Prescriptions[j]
+				if set.Weight != nil {
+					weight := scale(*set.Weight)
+					set.Weight = &weight
+				}
+				if set.Percent1RM != nil {
+					percent := float32(float64(*set.Percent1RM) * intensity)
+					set.Percent1RM = &percent
+				}
+			}
+		}
+		if volume == 1 {
+			continue
+		}
+		if len(exercise.Prescriptions) == 0 {
+			exercise.Sets = scaleCount(exercise.Sets, volume)
+			continue
+		}
+		exercise.Prescriptions = scaleWorkingSets(exercise.Prescriptions, volume)
+		exercise.Sets = int64(len(exercise.Prescriptions))
+	}
+}
+
+// scaleCount scales a number of sets by volume, keeping at least one.
+func scaleCount(n int64, volume float64) int64 {
+	if n == 0 {
+		return 0
+	}
+	return max(int64(math.Round(float64(n)*volume)), 1)
+}
+
+// scaleWorkingSets scales the working sets of sets by volume, dropping the
+// last ones or repeating the last one, and numbers the sets again.
+func scaleWorkingSets(sets []model.WorkoutPlanSet, volume float64) []model.WorkoutPlanSet {
+	working, last := int64(0), -1
+	for i, set := range sets {
+		if set.Type == model.SetWorking {
+			working, last = working+1, i
+		}
+	}
+	if working == 0 {
+		return sets
+	}
+	target := scaleCount(working, volume)
+	scaled := make([]model.WorkoutPlanSet, 0, len(sets)-int(working)+int(target))
+	kept := int64(0)
+	for i, set := range sets {
+		if set.Type == model.SetWorking {
+			if kept == target {
+				continue
+			}
+			kept++
+		}
+		scaled = append(scaled, set)
+		for ; i == last && kept < target; kept++ {
+			extra := set
+			extra.ID = 0
+			scaled = append(scaled, extra)
+		}
+	}
+	for i := range scaled {
+		scaled[i].Position = int64(i + 1)
+	}
+	return scaled
+}
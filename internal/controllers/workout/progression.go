@@ -0,0 +1,295 @@
+package controllers
+
+import (
+	"fmt"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/metrics"
+	"workout_tracker/pkg/units"
+
+	"github.com/jinzhu/gorm"
+)
+
+// loadTolerance is how much lighter than its target a logged set may be and
+// still count, so that loads entered in another unit system match.
+const loadTolerance = units.Decimal(50)
+
+// defaultDeloadPercent is the deload of rules that do not set one.
+const defaultDeloadPercent = 10
+
+// progressionTarget is a working set a plan exercise prescribes, with the
+// bottom and top of its rep range, and its logged match.
+type progressionTarget struct {
+	reps, maxReps int64
+	weight        units.Decimal
+	logged        *model.WorkoutSessionSet
+}
+
+// progressPlan evaluates the progression rules of the plan session followed,
+// moves the loads of the plan for its next scheduled workout, and records
+// each change with its explanation in the units of system. Exercises the
+// session logged no sets of are left alone, and so are plans followed in a
+// program week that changes their loads or sets, such as a deload. db should
+// be a transaction.
+func progressPlan(db *gorm.DB, session model.WorkoutSession, system units.System) ([]model.ProgressionAdjustment, *apperror.Error) {
+	workout := session.WorkoutPlan
+	if workout == nil {
+		return nil, nil
+	}
+	fail := func(err error) *apperror.Error {
+		return apperror.Database(err, "Failed to progress workout plan")
+	}
+	if session.WorkoutScheduleId != nil {
+		var schedule model.WorkoutSchedule
+		if err := db.Select("intensity_percent, volume_percent").First(&schedule, *session.WorkoutScheduleId).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+			return nil, fail(err)
+		}
+		if schedule.Modified() {
+			return nil, nil
+		}
+	}
+
+	var adjustments []model.ProgressionAdjustment
+	for i := range workout.Exercises {
+		exercise := &workout.Exercises[i]
+		if exercise.Progression == nil {
+			continue
+		}
+		targets := progressionTargets(*exercise, session.Sets)
+		if len(targets) == 0 || !anyLogged(targets) {
+			continue
+		}
+		adjustment, failures := evaluateProgression(*exercise, targets, system)
+		if err := db.Model(exercise.Progression).UpdateColumn("failures", failures).Error; err != nil {
+			return nil, fail(err)
+		}
+		if adjustment.Weight != adjustment.PreviousWeight {
+			if err := moveLoads(db, exercise, adjustment, system); err != nil {
+				return nil, fail(err)
+			}
+		}
+		adjustment.WorkoutSessionId = int64(session.ID)
+		adjustments = append(adjustments, adjustment)
+	}
+	if len(adjustments) == 0 {
+		return nil, nil
+	}
+
+	changed := false
+	for _, adjustment := range adjustments {
+		changed = changed || adjustment.Weight != adjustment.PreviousWeight
+	}
+	var next model.WorkoutSchedule
+	err := db.Where("user_id = ? AND workout_plan_id = ? AND status = ?", session.UserId, workout.ID, model.StatusScheduled).
+		Order("scheduled_date").First(&next).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, fail(err)
+	}
+	if changed {
+		if err := db.Model(&model.WorkoutPlan{}).Where("id = ?", workout.ID).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error; err != nil {
+			return nil, fail(err)
+		}
+		if next.ID != 0 {
+			if err := db.Model(&model.WorkoutSchedule{}).Where("id = ?", next.ID).
+				UpdateColumn("version", gorm.Expr("version + 1")).Error; err != nil {
+				return nil, fail(err)
+			}
+		}
+	}
+	for i := range adjustments {
+		if next.ID != 0 {
+			scheduleId := int64(next.ID)
+			adjustments[i].WorkoutScheduleId = &scheduleId
+		}
+		if err := db.Create(&adjustments[i]).Error; err != nil {
+			return nil, fail(err)
+		}
+	}
+	return adjustments, nil
+}
+
+// countAdjustments reports adjustments to the metrics.
+func countAdjustments(adjustments []model.ProgressionAdjustment) {
+	for _, adjustment := range adjustments {
+		metrics.ProgressionAdjustments.WithLabelValues(adjustment.Outcome).Inc()
+	}
+}
+
+// progressionTargets returns the working sets exercise prescribes with an
+// absolute load, each matched with the next set logged for it at that load
+// or heavier, so that lighter warm-up sets are passed over.
+func progressionTargets(exercise model.WorkoutPlanExercise, sets []model.WorkoutSessionSet) []progressionTarget {
+	var targets []progressionTarget
+	if len(exercise.Prescriptions) == 0 {
+		for i := int64(0); i < exercise.Sets; i++ {
+			targets = append(targets, progressionTarget{reps: exercise.Repetitions, maxReps: exercise.Repetitions, weight: exercise.Weight})
+		}
+	}
+	for _, set := range exercise.Prescriptions {
+		if set.Type != model.SetWorking || set.Weight == nil {
+			continue
+		}
+		maxReps := set.MaxReps
+		if maxReps == 0 {
+			maxReps = set.Reps
+		}
+		targets = append(targets, progressionTarget{reps: set.Reps, maxReps: maxReps, weight: *set.Weight})
+	}
+
+	next := 0
+	for i := range targets {
+		for ; next < len(sets); next++ {
+			set := sets[next]
+			if set.WorkoutPlanExerciseId != nil && *set.WorkoutPlanExerciseId == int64(exercise.ID) &&
+				set.Weight >= targets[i].weight-loadTolerance {
+				targets[i].logged = &sets[next]
+				next++
+				break
+			}
+		}
+	}
+	return targets
+}
+
+func anyLogged(targets []progressionTarget) bool {
+	for _, target := range targets {
+		if target.logged != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateProgression applies the progression rule of exercise to how its
+// targets went, returning the adjustment it makes and the failures in a row
+// the rule counts after it.
+func evaluateProgression(exercise model.WorkoutPlanExercise, targets []progressionTarget, system units.System) (model.ProgressionAdjustment, int64) {
+	rule := *exercise.Progression
+	load := func(d units.Decimal) string {
+		return units.Convert(system, units.Load, d).String() + " " + units.Symbol(system, units.Load)
+	}
+
+	hit, top := true, true
+	var reason string
+	var rpeTotal float64
+	var rpeCount int
+	for i, target := range targets {
+		set := target.logged
+		switch {
+		case set == nil:
+			if hit {
+				reason = fmt.Sprintf("Set %d of %d reps at %s was not logged", i+1, target.reps, load(target.weight))
+			}
+			hit, top = false, false
+			continue
+		case set.Reps < target.reps:
+			if hit {
+				reason = fmt.Sprintf("Set %d got %d of %d reps at %s", i+1, set.Reps, target.reps, load(target.weight))
+			}
+			hit, top = false, false
+		case set.Reps < target.maxReps:
+			top = false
+		}
+		if set.RPE != nil {
+			rpeTotal += float64(*set.RPE)
+			rpeCount++
+		}
+	}
+
+	adjustment := model.ProgressionAdjustment{
+		WorkoutPlanExerciseId: int64(exercise.ID),
+		ExerciseId:            exercise.ExerciseId,
+		Outcome:               model.OutcomeHeld,
+		PreviousWeight:        exercise.Weight,
+		Weight:                exercise.Weight,
+	}
+	switch {
+	case !hit:
+	case rule.Type == model.ProgressionLinear:
+		reason = "Hit every target"
+		adjustment.Outcome = model.OutcomeProgressed
+	case rule.Type == model.ProgressionDouble && top:
+		reason = "Reached the top of the rep range on every set"
+		adjustment.Outcome = model.OutcomeProgressed
+	case rule.Type == model.ProgressionDouble:
+		reason = "Hit the rep range without reaching its top on every set"
+	case rpeCount == 0:
+		reason = "Hit every target but logged no RPE"
+	default:
+		rpe := rpeTotal / float64(rpeCount)
+		reason = fmt.Sprintf("Hit every target at an average RPE of %.1f for a target of %.1f", rpe, rule.TargetRPE)
+		if rpe <= float64(rule.TargetRPE)-1 {
+			adjustment.Outcome = model.OutcomeProgressed
+		} else if rpe >= float64(rule.TargetRPE)+1 {
+			adjustment.Outcome = model.OutcomeReduced
+		}
+	}
+
+	failures := int64(0)
+	var consequence string
+	switch adjustment.Outcome {
+	case model.OutcomeProgressed:
+		adjustment.Weight = exercise.Weight + rule.Increment
+		consequence = fmt.Sprintf("the load goes up by %s to %s", load(rule.Increment), load(adjustment.Weight))
+	case model.OutcomeReduced:
+		adjustment.Weight = max(exercise.Weight-rule.Increment, 0)
+		consequence = fmt.Sprintf("the load goes down by %s to %s", load(rule.Increment), load(adjustment.Weight))
+	default:
+		consequence = "the load stays at " + load(exercise.Weight)
+		if hit {
+			break
+		}
+		failures = rule.Failures + 1
+		adjustment.Outcome = model.OutcomeFailed
+		if rule.DeloadAfter == 0 {
+			break
+		}
+		if failures < rule.DeloadAfter {
+			consequence += fmt.Sprintf(" (%d of %d misses in a row before a deload)", failures, rule.DeloadAfter)
+			break
+		}
+		percent := deloadPercent(rule)
+		adjustment.Outcome = model.OutcomeDeloaded
+		adjustment.Weight = deload(exercise.Weight, percent, system)
+		consequence = fmt.Sprintf("after %d misses in a row the load is deloaded by %g%% to %s", failures, percent, load(adjustment.Weight))
+		failures = 0
+	}
+	adjustment.Explanation = reason + ", so " + consequence + "."
+	return adjustment, failures
+}
+
+func deloadPercent(rule model.WorkoutPlanProgression) float64 {
+	if rule.DeloadPercent == 0 {
+		return defaultDeloadPercent
+	}
+	return float64(rule.DeloadPercent)
+}
+
+// deload takes percent off weight, rounded to the plates of system.
+func deload(weight units.Decimal, percent float64, system units.System) units.Decimal {
+	lighter := units.NewDecimal(weight.Float64() * (1 - percent/100))
+	return units.Round(system, units.Load, lighter, loadSteps[system])
+}
+
+// moveLoads moves the absolute loads of exercise along with its heaviest
+// load: by the same amount, or for a deload by the same percentage. db should
+// be a transaction.
+func moveLoads(db *gorm.DB, exercise *model.WorkoutPlanExercise, adjustment model.ProgressionAdjustment, system units.System) error {
+	move := func(weight units.Decimal) units.Decimal {
+		if adjustment.Outcome == model.OutcomeDeloaded {
+			return deload(weight, deloadPercent(*exercise.Progression), system)
+		}
+		return max(weight+adjustment.Weight-adjustment.PreviousWeight, 0)
+	}
+	for j := range exercise.Prescriptions {
+		set := &exercise.Prescriptions[j]
+		if set.Weight == nil {
+			continue
+		}
+		if err := db.Model(set).UpdateColumn("weight", move(*set.Weight)).Error; err != nil {
+			return err
+		}
+	}
+	return db.Model(exercise).UpdateColumn("weight", adjustment.Weight).Error
+}
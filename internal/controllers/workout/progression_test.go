@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"testing"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/pkg/units"
+)
+
+const planExerciseId = 7
+
+// straightSets is a plan exercise of 3 sets of 5 at 100 kg moved by rule.
+func straightSets(rule model.WorkoutPlanProgression) model.WorkoutPlanExercise {
+	return model.WorkoutPlanExercise{
+		ID:          planExerciseId,
+		ExerciseId:  1,
+		Sets:        3,
+		Repetitions: 5,
+		Weight:      units.NewDecimal(100),
+		Progression: &rule,
+	}
+}
+
+// repRange is a plan exercise of a warm-up and 2 working sets of 8 to 12 at
+// 60 kg moved by rule.
+func repRange(rule model.WorkoutPlanProgression) model.WorkoutPlanExercise {
+	warmup, working := units.NewDecimal(30), units.NewDecimal(60)
+	return model.WorkoutPlanExercise{
+		ID:         planExerciseId,
+		ExerciseId: 1,
+		Sets:       3,
+		Weight:     working,
+		Prescriptions: []model.WorkoutPlanSet{
+			{Type: model.SetWarmup, Reps: 10, Weight: &warmup},
+			{Type: model.SetWorking, Reps: 8, MaxReps: 12, Weight: &working},
+			{Type: model.SetWorking, Reps: 8, MaxReps: 12, Weight: &working},
+		},
+		Progression: &rule,
+	}
+}
+
+// logged is a set logged for the plan exercise; an rpe of zero is left out.
+func logged(weight float64, reps int64, rpe float32) model.WorkoutSessionSet {
+	id := int64(planExerciseId)
+	set := model.WorkoutSessionSet{WorkoutPlanExerciseId: &id, ExerciseId: 1, Weight: units.NewDecimal(weight), Reps: reps}
+	if rpe != 0 {
+		set.RPE = &rpe
+	}
+	return set
+}
+
+func TestEvaluateProgression(t *testing.T) {
+	linear := model.WorkoutPlanProgression{Type: model.ProgressionLinear, Increment: units.NewDecimal(2.5)}
+	double := model.WorkoutPlanProgression{Type: model.ProgressionDouble, Increment: units.NewDecimal(5)}
+	rpe := model.WorkoutPlanProgression{Type: model.ProgressionRPE, Increment: units.NewDecimal(2.5), TargetRPE: 8}
+
+	tests := []struct {
+		name         string
+		exercise     model.WorkoutPlanExercise
+		sets         []model.WorkoutSessionSet
+		wantOutcome  string
+		wantWeight   float64
+		wantFailures int64
+	}{
+		{
+			name:        "linear hits every target",
+			exercise:    straightSets(linear),
+			sets:        []model.WorkoutSessionSet{logged(100, 5, 0), logged(100, 5, 0), logged(100, 6, 0)},
+			wantOutcome: model.OutcomeProgressed,
+			wantWeight:  102.5,
+		},
+		{
+			name:         "linear misses reps",
+			exercise:     straightSets(linear),
+			sets:         []model.WorkoutSessionSet{logged(100, 5, 0), logged(100, 4, 0), logged(100, 5, 0)},
+			wantOutcome:  model.OutcomeFailed,
+			wantWeight:   100,
+			wantFailures: 1,
+		},
+		{
+			name:         "linear misses a set",
+			exercise:     straightSets(linear),
+			sets:         []model.WorkoutSessionSet{logged(100, 5, 0), logged(100, 5, 0)},
+			wantOutcome:  model.OutcomeFailed,
+			wantWeight:   100,
+			wantFailures: 1,
+		},
+		{
+			name:        "double reaches the top of the range",
+			exercise:    repRange(double),
+			sets:        []model.WorkoutSessionSet{logged(30, 10, 0), logged(60, 12, 0), logged(60, 12, 0)},
+			wantOutcome: model.OutcomeProgressed,
+			wantWeight:  65,
+		},
+		{
+			name:        "double stays in the range",
+			exercise:    repRange(double),
+			sets:        []model.WorkoutSessionSet{logged(30, 10, 0), logged(60, 12, 0), logged(60, 9, 0)},
+			wantOutcome: model.OutcomeHeld,
+			wantWeight:  60,
+		},
+		{
+			name:         "double falls below the range",
+			exercise:     repRange(double),
+			sets:         []model.WorkoutSessionSet{logged(30, 10, 0), logged(60, 12, 0), logged(60, 7, 0)},
+			wantOutcome:  model.OutcomeFailed,
+			wantWeight:   60,
+			wantFailures: 1,
+		},
+		{
+			name:        "rpe well under the target",
+			exercise:    straightSets(rpe),
+			sets:        []model.WorkoutSessionSet{logged(100, 5, 6), logged(100, 5, 7), logged(100, 5, 7)},
+			wantOutcome: model.OutcomeProgressed,
+			wantWeight:  102.5,
+		},
+		{
+			name:        "rpe near the target",
+			exercise:    straightSets(rpe),
+			sets:        []model.WorkoutSessionSet{logged(100, 5, 7.5), logged(100, 5, 8), logged(100, 5, 8.5)},
+			wantOutcome: model.OutcomeHeld,
+			wantWeight:  100,
+		},
+		{
+			name:        "rpe well over the target",
+			exercise:    straightSets(rpe),
+			sets:        []model.WorkoutSessionSet{logged(100, 5, 9), logged(100, 5, 9), logged(100, 5, 10)},
+			wantOutcome: model.OutcomeReduced,
+			wantWeight:  97.5,
+		},
+		{
+			name:        "rpe not logged",
+			exercise:    straightSets(rpe),
+			sets:        []model.WorkoutSessionSet{logged(100, 5, 0), logged(100, 5, 0), logged(100, 5, 0)},
+			wantOutcome: model.OutcomeHeld,
+			wantWeight:  100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets := progressionTargets(tt.exercise, tt.sets)
+			adjustment, failures := evaluateProgression(tt.exercise, targets, units.Metric)
+			if adjustment.Outcome != tt.wantOutcome || adjustment.Weight != units.NewDecimal(tt.wantWeight) || failures != tt.wantFailures {
+				t.Errorf("got %s to %s kg after %d failures, want %s to %v kg after %d (%s)",
+					adjustment.Outcome, adjustment.Weight, failures, tt.wantOutcome, tt.wantWeight, tt.wantFailures, adjustment.Explanation)
+			}
+			if adjustment.PreviousWeight != tt.exercise.Weight {
+				t.Errorf("previous weight = %s kg, want %s", adjustment.PreviousWeight, tt.exercise.Weight)
+			}
+		})
+	}
+}
+
+func TestEvaluateProgressionDeloads(t *testing.T) {
+	missed := []model.WorkoutSessionSet{logged(100, 5, 0), logged(100, 3, 0), logged(100, 2, 0)}
+
+	tests := []struct {
+		name         string
+		failures     int64
+		deloadPct    float32
+		system       units.System
+		weight       float64
+		wantOutcome  string
+		wantWeight   float64
+		wantFailures int64
+	}{
+		{"before the limit", 1, 0, units.Metric, 100, model.OutcomeFailed, 100, 2},
+		{"at the limit by the default percent", 2, 0, units.Metric, 100, model.OutcomeDeloaded, 90, 0},
+		{"at the limit by a set percent", 2, 20, units.Metric, 100, model.OutcomeDeloaded, 80, 0},
+		// 102.5 kg less 10% is 92.25 kg, rounded to 2.5 kg plates.
+		{"rounded to kilogram plates", 2, 0, units.Metric, 102.5, model.OutcomeDeloaded, 92.5, 0},
+		// 100 kg less 10% is 198.4 lb, rounded to 5 lb plates: 200 lb.
+		{"rounded to pound plates", 2, 0, units.Imperial, 100, model.OutcomeDeloaded, 90.718, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exercise := straightSets(model.WorkoutPlanProgression{
+				Type:          model.ProgressionLinear,
+				Increment:     units.NewDecimal(2.5),
+				DeloadAfter:   3,
+				DeloadPercent: tt.deloadPct,
+				Failures:      tt.failures,
+			})
+			exercise.Weight = units.NewDecimal(tt.weight)
+			sets := make([]model.WorkoutSessionSet, len(missed))
+			for i, set := range missed {
+				set.Weight = exercise.Weight
+				sets[i] = set
+			}
+
+			adjustment, failures := evaluateProgression(exercise, progressionTargets(exercise, sets), tt.system)
+			if adjustment.Outcome != tt.wantOutcome || adjustment.Weight != units.NewDecimal(tt.wantWeight) || failures != tt.wantFailures {
+				t.Errorf("got %s to %s kg with %d failures, want %s to %v kg with %d (%s)",
+					adjustment.Outcome, adjustment.Weight, failures, tt.wantOutcome, tt.wantWeight, tt.wantFailures, adjustment.Explanation)
+			}
+		})
+	}
+}
+
+func TestProgressionTargetsSkipWarmups(t *testing.T) {
+	otherExercise := logged(60, 12, 0)
+	other := int64(planExerciseId + 1)
+	otherExercise.WorkoutPlanExerciseId = &other
+
+	tests := []struct {
+		name string
+		sets []model.WorkoutSessionSet
+		// want is the index in sets of the set each working set matches, or
+		// -1 for none.
+		want []int
+	}{
+		{
+			name: "warm-ups before the working sets",
+			sets: []model.WorkoutSessionSet{logged(30, 10, 0), logged(45, 5, 0), logged(60, 10, 0), logged(60, 8, 0)},
+			want: []int{2, 3},
+		},
+		{
+			// 132.3 lb, entered for 60 kg, is 60.01 kg; 132.2 lb is 59.965.
+			name: "within the tolerance of another unit",
+			sets: []model.WorkoutSessionSet{logged(59.965, 10, 0), logged(60.01, 10, 0)},
+			want: []int{0, 1},
+		},
+		{
+			name: "lighter than the tolerance",
+			sets: []model.WorkoutSessionSet{logged(59.9, 10, 0), logged(60, 10, 0)},
+			want: []int{1, -1},
+		},
+		{
+			name: "sets of another plan exercise",
+			sets: []model.WorkoutSessionSet{otherExercise, logged(60, 10, 0)},
+			want: []int{1, -1},
+		},
+		{
+			name: "heavier than prescribed",
+			sets: []model.WorkoutSessionSet{logged(62.5, 10, 0), logged(65, 8, 0)},
+			want: []int{0, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets := progressionTargets(repRange(model.WorkoutPlanProgression{Type: model.ProgressionDouble}), tt.sets)
+			if len(targets) != len(tt.want) {
+				t.Fatalf("got %d targets, want one per working set: %d", len(targets), len(tt.want))
+			}
+			for i, target := range targets {
+				got := -1
+				for j := range tt.sets {
+					if target.logged == &tt.sets[j] {
+						got = j
+					}
+				}
+				if got != tt.want[i] {
+					t.Errorf("working set %d matched set %d, want %d", i+1, got, tt.want[i])
+				}
+				if target.reps != 8 || target.maxReps != 12 || target.weight != units.NewDecimal(60) {
+					t.Errorf("working set %d targets %d-%d reps at %s kg, want 8-12 at 60", i+1, target.reps, target.maxReps, target.weight)
+				}
+			}
+		})
+	}
+}
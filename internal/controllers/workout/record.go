@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"net/http"
+	"workout_tracker/internal/config"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/metrics"
+	"workout_tracker/pkg/pagination"
+	"workout_tracker/pkg/units"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+type RecordListQuery struct {
+	pagination.Params
+	ExerciseId int64  `form:"exercise_id" binding:"omitempty,gt=0"`
+	Type       string `form:"type" binding:"omitempty,oneof=heaviest_weight most_reps estimated_1rm session_volume fastest_time"`
+	Current    bool   `form:"current"`
+}
+
+var recordSorts = pagination.Sortable{"achieved_at": "achieved_at"}
+
+// supersededRecord matches a record beaten by a later one of the same user,
+// exercise and type.
+const supersededRecord = `EXISTS (SELECT 1 FROM personal_records later
+	WHERE later.user_id = personal_records.user_id AND later.exercise_id = personal_records.exercise_id
+	AND later.type = personal_records.type AND later.id > personal_records.id
+	AND ((later.type = 'heaviest_weight' AND later.weight >= personal_records.weight)
+	OR (later.type = 'most_reps' AND later.weight >= personal_records.weight AND later.reps >= personal_records.reps)
+	OR (later.type = 'estimated_1rm' AND later.estimated_1rm >= personal_records.estimated_1rm)
+	OR (later.type = 'session_volume' AND later.volume >= personal_records.volume)
+	OR (later.type = 'fastest_time' AND later.distance_meters >= personal_records.distance_meters
+		AND later.duration_seconds <= personal_records.duration_seconds)))`
+
+// @Tags Workout
+// @Summary Get user personal records
+// @Description Get a page of the personal records history of the authenticated user. Records are detected when sets are logged in a workout session.
+// @Param limit query int false "Page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "achieved_at, prefixed with - for descending" default(-achieved_at)
+// @Param exercise_id query int false "Only records on this exercise"
+// @Param type query string false "heaviest_weight, most_reps, estimated_1rm, session_volume or fastest_time"
+// @Param current query bool false "Only records that have not been beaten since"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.PersonalRecord
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /records [get]
+func GetRecords(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	var query RecordListQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	page, pageErr := pagination.New(query.Params, recordSorts, "-achieved_at")
+	if pageErr != nil {
+		apperror.Abort(c, pageErr)
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	db = db.Where("user_id = ?", userId)
+	if query.ExerciseId != 0 {
+		db = db.Where("exercise_id = ?", query.ExerciseId)
+	}
+	if query.Type != "" {
+		db = db.Where("type = ?", query.Type)
+	}
+	if query.Current {
+		db = db.Where("NOT " + supersededRecord)
+	}
+	records, meta, err := pagination.Find[model.PersonalRecord](db, page)
+	if err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to retrieve records"))
+		return
+	}
+	units.FromSI(system, &records)
+	c.JSON(http.StatusOK, gin.H{"message": "Records retrieved successfully", "data": records, "pagination": meta})
+}
+
+// recordBook is the records of a user on an exercise that sets are compared
+// against.
+type recordBook []model.PersonalRecord
+
+// beats reports whether record is better than every record of its type in
+// the book. A most_reps record must beat those at its weight or above, and a
+// fastest_time record those over its distance or more.
+func (b recordBook) beats(record model.PersonalRecord) bool {
+	for _, best := range b {
+		if best.Type != record.Type {
+			continue
+		}
+		var beaten bool
+		switch record.Type {
+		case model.RecordHeaviestWeight:
+			beaten = best.Weight >= record.Weight
+		case model.RecordMostReps:
+			beaten = best.Weight >= record.Weight && best.Reps >= record.Reps
+		case model.RecordEstimated1RM:
+			beaten = best.Estimated1RM >= record.Estimated1RM
+		case model.RecordSessionVolume:
+			beaten = best.Volume >= record.Volume
+		case model.RecordFastestTime:
+			beaten = best.DistanceMeters >= record.DistanceMeters && best.DurationSeconds <= record.DurationSeconds
+		}
+		if beaten {
+			return false
+		}
+	}
+	return true
+}
+
+// setRecords returns the records a set could set, before comparing them,
+// estimating its one-rep max with formula.
+func setRecords(set model.WorkoutSessionSet, formula string) []model.PersonalRecord {
+	setId := int64(set.ID)
+	record := func(kind string) model.PersonalRecord {
+		return model.PersonalRecord{
+			ExerciseId:          set.ExerciseId,
+			Type:                kind,
+			WorkoutSessionId:    set.WorkoutSessionId,
+			WorkoutSessionSetId: &setId,
+			AchievedAt:          set.PerformedAt,
+		}
+	}
+	var records []model.PersonalRecord
+	if set.Weight > 0 {
+		heaviest := record(model.RecordHeaviestWeight)
+		heaviest.Weight, heaviest.Reps = set.Weight, set.Reps
+		records = append(records, heaviest)
+	}
+	if set.Reps > 0 {
+		most := record(model.RecordMostReps)
+		most.Weight, most.Reps = set.Weight, set.Reps
+		records = append(records, most)
+	}
+	if e1rm := estimate1RM(formula, set.Weight, set.Reps); e1rm > 0 {
+		estimated := record(model.RecordEstimated1RM)
+		estimated.Weight, estimated.Reps, estimated.Estimated1RM = set.Weight, set.Reps, e1rm
+		records = append(records, estimated)
+	}
+	if set.DistanceMeters > 0 && set.DurationSeconds > 0 {
+		fastest := record(model.RecordFastestTime)
+		fastest.DistanceMeters, fastest.DurationSeconds = set.DistanceMeters, set.DurationSeconds
+		records = append(records, fastest)
+	}
+	return records
+}
+
+// detectRecords works out again the records session sets on each of the
+// given exercises from the sets it has now, replacing those it had, and
+// returns the records it did not have before. db should be a transaction.
+func detectRecords(db *gorm.DB, session model.WorkoutSession, exerciseIds ...int64) ([]model.PersonalRecord, *apperror.Error) {
+	formula, err := oneRMFormula(db, session.UserId, "")
+	if err != nil {
+		return nil, err
+	}
+	var created []model.PersonalRecord
+	for _, exerciseId := range uniqueIds(exerciseIds) {
+		records, err := detectExerciseRecords(db, session, exerciseId, formula)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, records...)
+	}
+	return created, nil
+}
+
+func detectExerciseRecords(db *gorm.DB, session model.WorkoutSession, exerciseId int64, formula string) ([]model.PersonalRecord, *apperror.Error) {
+	fail := func(err error) *apperror.Error {
+		return apperror.Database(err, "Failed to detect personal records")
+	}
+
+	var previous []model.PersonalRecord
+	if err := db.Where("workout_session_id = ? AND exercise_id = ?", session.ID, exerciseId).Find(&previous).Error; err != nil {
+		return nil, fail(err)
+	}
+	if err := db.Where("workout_session_id = ? AND exercise_id = ?", session.ID, exerciseId).Delete(&model.PersonalRecord{}).Error; err != nil {
+		return nil, fail(err)
+	}
+	var book recordBook
+	if err := db.Where("user_id = ? AND exercise_id = ?", session.UserId, exerciseId).Find(&book).Error; err != nil {
+		return nil, fail(err)
+	}
+	var sets []model.WorkoutSessionSet
+	if err := db.Where("workout_session_id = ? AND exercise_id = ?", session.ID, exerciseId).Order("position").Find(&sets).Error; err != nil {
+		return nil, fail(err)
+	}
+
+	var records []model.PersonalRecord
+	var volume float64
+	for _, set := range sets {
+		for _, record := range setRecords(set, formula) {
+			if book.beats(record) {
+				records = append(records, record)
+				book = append(book, record)
+			}
+		}
+		volume += float64(set.Reps) * set.Weight.Float64()
+	}
+	if len(sets) > 0 && volume > 0 {
+		record := model.PersonalRecord{
+			ExerciseId:       exerciseId,
+			Type:             model.RecordSessionVolume,
+			Volume:           units.NewDecimal(volume),
+			WorkoutSessionId: int64(session.ID),
+			AchievedAt:       sets[len(sets)-1].PerformedAt,
+		}
+		if book.beats(record) {
+			records = append(records, record)
+		}
+	}
+
+	var created []model.PersonalRecord
+	for _, record := range records {
+		record.UserId = session.UserId
+		if err := db.Create(&record).Error; err != nil {
+			return nil, fail(err)
+		}
+		if !hadRecord(previous, record) {
+			created = append(created, record)
+		}
+	}
+	return created, nil
+}
+
+// hadRecord reports whether records has one of the same type as record, set
+// by the same set, or by the same session for a session-wide record.
+func hadRecord(records []model.PersonalRecord, record model.PersonalRecord) bool {
+	for _, had := range records {
+		if had.Type != record.Type {
+			continue
+		}
+		switch {
+		case had.WorkoutSessionSetId == nil && record.WorkoutSessionSetId == nil:
+			return true
+		case had.WorkoutSessionSetId == nil || record.WorkoutSessionSetId == nil:
+			continue
+		case *had.WorkoutSessionSetId == *record.WorkoutSessionSetId:
+			return true
+		}
+	}
+	return false
+}
+
+// countRecords reports new records to the metrics.
+func countRecords(records []model.PersonalRecord) {
+	for _, record := range records {
+		metrics.RecordsSet.WithLabelValues(record.Type).Inc()
+	}
+}
+
+func uniqueIds(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	var unique []int64
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -1,171 +1,331 @@
 package controllers
 
 import (
+	"encoding/json"
 	"net/http"
 	"time"
 	"workout_tracker/internal/config"
 	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/etag"
+	"workout_tracker/pkg/metrics"
+	"workout_tracker/pkg/pagination"
+	"workout_tracker/pkg/units"
 	"workout_tracker/pkg/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
 )
 
 type WorkoutSchedule struct {
-	WorkoutPlanId int64     `json:"workout_plan_id"`
-	ScheduledDate time.Time `json:"scheduled_date"`
-	Status        string    `json:"status"`
+	WorkoutPlanId int64     `json:"workout_plan_id" binding:"required,gt=0,workout_plan"`
+	ScheduledDate time.Time `json:"scheduled_date" binding:"required"`
+	Status        string    `json:"status" binding:"omitempty,oneof=scheduled completed skipped cancelled"`
 	CompletedDate time.Time `json:"completed_date"`
 }
 
+type ScheduleListQuery struct {
+	pagination.Params
+	Status string    `form:"status" binding:"omitempty,oneof=scheduled completed skipped cancelled"`
+	From   time.Time `form:"from" time_format:"2006-01-02"`
+	To     time.Time `form:"to" time_format:"2006-01-02" binding:"omitempty,gtefield=From"`
+}
+
 type WorkoutScheduleDetails struct {
-	ScheduledDate time.Time         `json:"scheduled_date"`
-	Status        string            `json:"status"`
-	CompletedDate time.Time         `json:"completed_date"`
-	WorkoutPlan   model.WorkoutPlan `json:"workout_plan"`
+	ScheduledDate       time.Time         `json:"scheduled_date"`
+	Status              string            `json:"status"`
+	CompletedDate       time.Time         `json:"completed_date"`
+	Version             int64             `json:"version"`
+	ProgramEnrollmentId *int64            `json:"program_enrollment_id,omitempty"`
+	ProgramWeek         int64             `json:"program_week,omitempty"`
+	IntensityPercent    float64           `json:"intensity_percent,omitempty"`
+	VolumePercent       float64           `json:"volume_percent,omitempty"`
+	WorkoutPlan         model.WorkoutPlan `json:"workout_plan"`
 }
 
+var scheduleSorts = pagination.Sortable{"scheduled_date": "scheduled_date", "created_at": "created_at", "status": "status"}
+
 // @Tags Workout
 // @Summary Get user workout schedule
-// @Description Get the workout schedule of the authenticated user
+// @Description Get a page of the workout schedule of the authenticated user
+// @Param limit query int false "Page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "scheduled_date, created_at or status, prefixed with - for descending" default(scheduled_date)
+// @Param status query string false "Only schedules with this status"
+// @Param from query string false "Only schedules on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Only schedules on or before this date (YYYY-MM-DD)"
 // @Accept json
 // @Produce json
-// @Success 200 {object} WorkoutSchedule
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {array} model.WorkoutSchedule
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts/schedules [get]
 func GetMyWorkoutSchedules(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
-	var schedules []WorkoutSchedule
-	if err := config.GetDB().Find(&schedules, map[string]interface{}{"user_id": userId}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+	var query ScheduleListQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-	if len(schedules) == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No workout schedules found"})
+	listSchedules(c, userId, query)
+}
+
+// listSchedules responds with the page of the user's schedules matching
+// query.
+func listSchedules(c *gin.Context, userId int64, query ScheduleListQuery) {
+	page, pageErr := pagination.New(query.Params, scheduleSorts, "scheduled_date")
+	if pageErr != nil {
+		apperror.Abort(c, pageErr)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "All schedules retrieved successfully", "data": schedules})
+
+	db := config.GetDBContext(c.Request.Context()).Where("user_id = ?", userId)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if !query.From.IsZero() {
+		db = db.Where("scheduled_date >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		db = db.Where("scheduled_date < ?", query.To.AddDate(0, 0, 1))
+	}
+	schedules, meta, err := pagination.Find[model.WorkoutSchedule](db, page)
+	if err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to retrieve schedules"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Schedules retrieved successfully", "data": schedules, "pagination": meta})
 }
 
 // @Tags Workout
 // @Summary Get user workout schedule by id
-// @Description Get the workout schedule of the authenticated user by id
+// @Description Get the workout schedule of the authenticated user by id. Prescriptions given as a percentage of the one-rep max come with the weight it resolves to from the user's training max, rounded to 2.5 kg or 5 lb. Schedules of a program week have their loads and sets scaled by the week's intensity and volume percentages. Send the ETag of a previous response in If-None-Match to get 304 Not Modified while the schedule, its plan, the units and the training maxes behind its loads are unchanged.
 // @Param id path int true "Workout ID"
+// @Param If-None-Match header string false "ETag of the cached schedule"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
 // @Accept json
 // @Produce json
 // @Success 200 {object} WorkoutSchedule
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Header 200 {string} ETag "Version of the schedule and of the body it is shown with"
+// @Success 304
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts/schedules/{id} [get]
 func GetScheduleByID(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
 	scheduleId := c.Params.ByName("id")
 	if scheduleId == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Schedule Id is required!"})
+		apperror.Abort(c, apperror.BadRequest("schedule_id_required", "Schedule Id is required"))
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
 		return
 	}
 
 	var schedule model.WorkoutSchedule
-	if err := config.GetDB().First(&schedule, map[string]interface{}{"ID": scheduleId, "user_id": userId}).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Workout schedule not found"})
+	if err := db.First(&schedule, map[string]interface{}{"id": scheduleId, "user_id": userId}).Error; err != nil {
+		apperror.Abort(c, apperror.Lookup(err, "schedule_not_found", "Workout schedule not found"))
 		return
 	}
 
 	var workout model.WorkoutPlan
-	if err := config.GetDB().First(&workout, map[string]interface{}{"ID": schedule.WorkoutPlanId}).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Workout plan not found"})
+	if err := withExercises(db).First(&workout, map[string]interface{}{"id": schedule.WorkoutPlanId}).Error; err != nil {
+		apperror.Abort(c, apperror.Lookup(err, "workout_not_found", "Workout plan not found"))
+		return
+	}
+	applyProgramWeek(&workout, schedule, system)
+	if err := resolvePercentLoads(db, userId, system, &workout); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 
 	response := WorkoutScheduleDetails{
-		ScheduledDate: schedule.ScheduledDate,
-		Status:        schedule.Status,
-		CompletedDate: schedule.CompletedDate,
-		WorkoutPlan:   workout,
+		ScheduledDate:       schedule.ScheduledDate,
+		Status:              schedule.Status,
+		CompletedDate:       schedule.CompletedDate,
+		Version:             schedule.Version,
+		ProgramEnrollmentId: schedule.ProgramEnrollmentId,
+		ProgramWeek:         schedule.ProgramWeek,
+		IntensityPercent:    schedule.IntensityPercent,
+		VolumePercent:       schedule.VolumePercent,
+		WorkoutPlan:         workout,
+	}
+	units.FromSI(system, &response)
+
+	// The body depends on more than the schedule's version: the plan, the
+	// units and the training maxes behind percentage loads all shape it, so
+	// the tag is taken from the body itself.
+	body, err := json.Marshal(response)
+	if err != nil {
+		apperror.Abort(c, apperror.Internal("Failed to encode schedule", err))
+		return
+	}
+	if etag.NotModifiedTag(c, etag.ForRepresentation(schedule.Version, string(body))) {
+		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Schedules retrieved successfully", "data": response})
 }
 
 // @Tags Workout
 // @Summary Filter user workout schedule by status
-// @Description Filter the workout schedule of the authenticated user by status
+// @Description Get a page of the workout schedule of the authenticated user with the given status. Takes the same parameters as GET /workouts/schedules.
 // @Param status query string true "Workout Status"
+// @Param limit query int false "Page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "scheduled_date, created_at or status, prefixed with - for descending" default(scheduled_date)
 // @Accept json
 // @Produce json
-// @Success 200 {object} WorkoutSchedule
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {array} model.WorkoutSchedule
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts/schedules/status [get]
 func FilterByStatus(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
-		return
-	}
-
-	status := c.Query("status")
-	if status == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Status is required!"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
-	var schedules []WorkoutSchedule
-	if err := config.GetDB().Find(&schedules, map[string]interface{}{"user_id": userId, "status": status}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve schedules"})
+	var query ScheduleListQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-
-	if len(schedules) == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No workout schedules found"})
+	if query.Status == "" {
+		apperror.Abort(c, apperror.Validation(apperror.FieldError{Field: "status", Code: "required", Message: "is required"}))
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Schedules retrieved successfully", "data": schedules})
+	listSchedules(c, userId, query)
 }
 
 // @Tags Workout
 // @Summary Create new user workout schedule
 // @Description Create a new workout schedule for the authenticated user
 // @Param schedule body WorkoutSchedule true "Workout Schedule"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
 // @Accept json
 // @Produce json
 // @Success 200 {object} WorkoutSchedule
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts/schedules [post]
 func CreateSchedule(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
-	schedule := model.WorkoutSchedule{}
-	if err := c.ShouldBindJSON(&schedule); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	c.Set(validation.UserIDKey, userId)
+	var input WorkoutSchedule
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 
-	schedule.UserId = userId
-	if err := config.GetDB().Create(&schedule).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create schedule"})
+	schedule := newWorkoutSchedule(userId, input)
+	if err := config.GetDBContext(c.Request.Context()).Create(&schedule).Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to create schedule"))
 		return
 	}
+	metrics.SchedulesCreated.Inc()
+	if schedule.Status == model.StatusCompleted {
+		metrics.SchedulesCompleted.Inc()
+	}
 	c.JSON(http.StatusCreated, gin.H{"message": "Schedule created successfully", "data": schedule})
 }
+
+// findSchedule loads one of the user's workout schedules.
+func findSchedule(db *gorm.DB, userId int64, id interface{}) (model.WorkoutSchedule, *apperror.Error) {
+	var schedule model.WorkoutSchedule
+	if err := db.First(&schedule, map[string]interface{}{"id": id, "user_id": userId}).Error; err != nil {
+		return schedule, apperror.Lookup(err, "schedule_not_found", "Workout schedule not found")
+	}
+	return schedule, nil
+}
+
+func newWorkoutSchedule(userId int64, input WorkoutSchedule) model.WorkoutSchedule {
+	schedule := model.WorkoutSchedule{
+		UserId:        userId,
+		WorkoutPlanId: input.WorkoutPlanId,
+		ScheduledDate: input.ScheduledDate,
+		Status:        input.Status,
+		CompletedDate: input.CompletedDate,
+	}
+	if schedule.Status == "" {
+		schedule.Status = model.StatusScheduled
+	}
+	return schedule
+}
+
+// editableSchedule returns the fields of schedule a client may change.
+func editableSchedule(schedule model.WorkoutSchedule) WorkoutSchedule {
+	return WorkoutSchedule{
+		WorkoutPlanId: schedule.WorkoutPlanId,
+		ScheduledDate: schedule.ScheduledDate,
+		Status:        schedule.Status,
+		CompletedDate: schedule.CompletedDate,
+	}
+}
+
+// saveSchedule writes input over schedule and bumps its version, failing if
+// the schedule changed since it was read. schedule is reloaded on success.
+func saveSchedule(db *gorm.DB, schedule *model.WorkoutSchedule, input WorkoutSchedule, ifMatch string) *apperror.Error {
+	status := input.Status
+	if status == "" {
+		status = model.StatusScheduled
+	}
+	updates := map[string]interface{}{
+		"workout_plan_id": input.WorkoutPlanId,
+		"scheduled_date":  input.ScheduledDate,
+		"status":          status,
+		"completed_date":  input.CompletedDate,
+		"version":         gorm.Expr("version + 1"),
+	}
+	result := db.Model(&model.WorkoutSchedule{}).Where("id = ? AND version = ?", schedule.ID, schedule.Version).Updates(updates)
+	if result.Error != nil {
+		return apperror.Database(result.Error, "Failed to update schedule")
+	}
+	if result.RowsAffected == 0 {
+		return etag.Stale(ifMatch)
+	}
+	if err := db.First(schedule, schedule.ID).Error; err != nil {
+		return apperror.Database(err, "Failed to retrieve updated schedule")
+	}
+	return nil
+}
+
+// deleteSchedule soft-deletes schedule, failing if it changed since it was
+// read.
+func deleteSchedule(db *gorm.DB, schedule *model.WorkoutSchedule, ifMatch string) *apperror.Error {
+	result := db.Where("version = ?", schedule.Version).Delete(schedule)
+	if result.Error != nil {
+		return apperror.Database(result.Error, "Failed to delete schedule")
+	}
+	if result.RowsAffected == 0 {
+		return etag.Stale(ifMatch)
+	}
+	return nil
+}
@@ -0,0 +1,719 @@
+package controllers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"workout_tracker/internal/config"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/metrics"
+	"workout_tracker/pkg/pagination"
+	"workout_tracker/pkg/units"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+// StartSession starts a session on its own, from a plan, or from a
+// schedule, in which case the session follows the schedule's plan.
+type StartSession struct {
+	WorkoutScheduleId int64  `json:"workout_schedule_id" binding:"omitempty,gt=0"`
+	WorkoutPlanId     int64  `json:"workout_plan_id" binding:"omitempty,gt=0,workout_plan"`
+	Notes             string `json:"notes" binding:"max=1000"`
+}
+
+type FinishSession struct {
+	Notes string `json:"notes" binding:"max=1000"`
+}
+
+// LoggedSet is a set as performed. workout_plan_exercise_id links it to the
+// exercise of the session's plan it was prescribed by. Which of reps,
+// duration_seconds and distance must be set depends on how the exercise is
+// measured. Weights and distances are in the units of the request.
+type LoggedSet struct {
+	WorkoutPlanExerciseId int64         `json:"workout_plan_exercise_id" binding:"omitempty,gt=0"`
+	ExerciseId            int64         `json:"exercise_id" binding:"required,gt=0,exercise"`
+	Reps                  int64         `json:"reps" binding:"gte=0,max=1000"`
+	Weight                units.Decimal `json:"weight" binding:"gte=0,max_load=2000" unit:"load"`
+	RPE                   *float32      `json:"rpe" binding:"omitempty,min=1,max=10"`
+	Notes                 string        `json:"notes" binding:"max=1000"`
+	Cardio
+}
+
+type SessionListQuery struct {
+	pagination.Params
+	Status string `form:"status" binding:"omitempty,oneof=in_progress finished"`
+}
+
+var sessionSorts = pagination.Sortable{"started_at": "started_at"}
+
+// @Tags Workout
+// @Summary Get user workout sessions
+// @Description Get a page of the workout sessions of the authenticated user, without their sets
+// @Param limit query int false "Page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "started_at, prefixed with - for descending" default(-started_at)
+// @Param status query string false "in_progress or finished"
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.WorkoutSession
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/sessions [get]
+func GetMySessions(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	var query SessionListQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	page, pageErr := pagination.New(query.Params, sessionSorts, "-started_at")
+	if pageErr != nil {
+		apperror.Abort(c, pageErr)
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context()).Where("user_id = ?", userId)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	sessions, meta, err := pagination.Find[model.WorkoutSession](db, page)
+	if err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to retrieve sessions"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Sessions retrieved successfully", "data": sessions, "pagination": meta})
+}
+
+// @Tags Workout
+// @Summary Get user workout session by id
+// @Description Get a workout session of the authenticated user with its logged sets and, when it follows a plan, the plan's prescriptions
+// @Param id path int true "Session ID"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.WorkoutSession
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/sessions/{id} [get]
+func GetSessionByID(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	session, lookupErr := findSession(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	units.FromSI(system, &session)
+	c.JSON(http.StatusOK, gin.H{"message": "Session retrieved successfully", "data": session})
+}
+
+// @Tags Workout
+// @Summary Start a workout session
+// @Description Start a workout session for the authenticated user, optionally following one of their plans or opening one of their scheduled workouts. A user can only have one session in progress.
+// @Param session body StartSession true "Session"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 201 {object} model.WorkoutSession
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/sessions [post]
+func StartWorkoutSession(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	c.Set(validation.UserIDKey, userId)
+	var input StartSession
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	var running int
+	if err := db.Model(&model.WorkoutSession{}).Where("user_id = ? AND status = ?", userId, model.SessionInProgress).Count(&running).Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to start session"))
+		return
+	}
+	if running > 0 {
+		apperror.Abort(c, apperror.Conflict("session_in_progress", "Finish the session in progress before starting another"))
+		return
+	}
+
+	session := model.WorkoutSession{
+		UserId:    userId,
+		Status:    model.SessionInProgress,
+		StartedAt: gorm.NowFunc(),
+		Notes:     input.Notes,
+	}
+	if input.WorkoutPlanId != 0 {
+		session.WorkoutPlanId = &input.WorkoutPlanId
+	}
+	if input.WorkoutScheduleId != 0 {
+		schedule, lookupErr := findSchedule(db, userId, input.WorkoutScheduleId)
+		if lookupErr != nil {
+			apperror.Abort(c, lookupErr)
+			return
+		}
+		if schedule.Status != model.StatusScheduled {
+			apperror.Abort(c, apperror.Conflict("schedule_not_open", "Only a scheduled workout can be started, this one is "+schedule.Status))
+			return
+		}
+		if session.WorkoutPlanId != nil && *session.WorkoutPlanId != schedule.WorkoutPlanId {
+			apperror.Abort(c, apperror.Validation(apperror.FieldError{
+				Field:   "workout_plan_id",
+				Code:    "schedule_mismatch",
+				Message: "must be the plan of the schedule",
+			}))
+			return
+		}
+		session.WorkoutScheduleId = &input.WorkoutScheduleId
+		session.WorkoutPlanId = &schedule.WorkoutPlanId
+	}
+
+	// The count above gives the common case a clear answer; the unique index
+	// on sessions in progress settles two starts racing past it.
+	if err := db.Create(&session).Error; err != nil {
+		apperror.Abort(c, apperror.Unique(err, "session_in_progress", "Finish the session in progress before starting another"))
+		return
+	}
+	session, lookupErr := findSession(db, userId, session.ID)
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	units.FromSI(system, &session)
+	c.JSON(http.StatusCreated, gin.H{"message": "Session started successfully", "data": session})
+}
+
+// @Tags Workout
+// @Summary Log a set in a workout session
+// @Description Log a performed set in a workout session of the authenticated user that is in progress. Personal records the set beats are listed in its records, and records for the volume of the session in those of the session.
+// @Param id path int true "Session ID"
+// @Param set body LoggedSet true "Set"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 201 {object} model.WorkoutSession
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/sessions/{id}/sets [post]
+func LogSessionSet(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	session, lookupErr := openSession(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+
+	var input LoggedSet
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	units.ToSI(system, &input)
+	if err := checkLoggedSet(db, session, input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	set := newSessionSet(input)
+	set.WorkoutSessionId = int64(session.ID)
+	set.PerformedAt = gorm.NowFunc()
+	tx := db.Begin()
+	if err := lockSession(tx, session); err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to log set"))
+		return
+	}
+	if err := tx.Model(&model.WorkoutSessionSet{}).Where("workout_session_id = ?", session.ID).
+		Select("COALESCE(MAX(position), 0) + 1").Row().Scan(&set.Position); err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to log set"))
+		return
+	}
+	if err := tx.Create(&set).Error; err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to log set"))
+		return
+	}
+	records, detectErr := detectRecords(tx, session, set.ExerciseId)
+	if detectErr != nil {
+		tx.Rollback()
+		apperror.Abort(c, detectErr)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to log set"))
+		return
+	}
+	metrics.SetsLogged.Inc()
+	countRecords(records)
+
+	session, lookupErr = findSession(db, userId, session.ID)
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	units.FromSI(system, &session)
+	c.JSON(http.StatusCreated, gin.H{"message": "Set logged successfully", "data": session})
+}
+
+// @Tags Workout
+// @Summary Update a logged set
+// @Description Correct a set logged in a workout session of the authenticated user that is in progress, with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json
+// @Param id path int true "Session ID"
+// @Param set_id path int true "Set ID"
+// @Param set body LoggedSet true "Merge patch with the fields to change"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Success 200 {object} model.WorkoutSession
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 415 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/sessions/{id}/sets/{set_id} [patch]
+func UpdateSessionSet(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	session, lookupErr := openSession(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	set, lookupErr := findSessionSet(session, c.Param("set_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+
+	stored, shown := editableSessionSet(set), editableSessionSet(set)
+	units.FromSI(system, &shown)
+	input := shown
+	if err := validation.BindPatch(c, &input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	units.MergeSI(system, &input, &shown, &stored)
+	if err := checkLoggedSet(db, session, input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	changed := newSessionSet(input)
+	updates := map[string]interface{}{
+		"workout_plan_exercise_id": changed.WorkoutPlanExerciseId,
+		"exercise_id":              changed.ExerciseId,
+		"reps":                     changed.Reps,
+		"weight":                   changed.Weight,
+		"rpe":                      changed.RPE,
+		"notes":                    changed.Notes,
+	}
+	for column, value := range cardioColumns(changed.Cardio) {
+		updates[column] = value
+	}
+	tx := db.Begin()
+	if err := tx.Model(&set).Updates(updates).Error; err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to update set"))
+		return
+	}
+	records, detectErr := detectRecords(tx, session, set.ExerciseId, changed.ExerciseId)
+	if detectErr != nil {
+		tx.Rollback()
+		apperror.Abort(c, detectErr)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to update set"))
+		return
+	}
+	countRecords(records)
+
+	session, lookupErr = findSession(db, userId, session.ID)
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	units.FromSI(system, &session)
+	c.JSON(http.StatusOK, gin.H{"message": "Set updated successfully", "data": session})
+}
+
+// @Tags Workout
+// @Summary Delete a logged set
+// @Description Delete a set logged in a workout session of the authenticated user that is in progress. The sets after it move up by one.
+// @Param id path int true "Session ID"
+// @Param set_id path int true "Set ID"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.WorkoutSession
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/sessions/{id}/sets/{set_id} [delete]
+func DeleteSessionSet(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	session, lookupErr := openSession(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	set, lookupErr := findSessionSet(session, c.Param("set_id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+
+	tx := db.Begin()
+	if err := lockSession(tx, session); err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to delete set"))
+		return
+	}
+	if err := tx.Delete(&set).Error; err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to delete set"))
+		return
+	}
+	if err := tx.Model(&model.WorkoutSessionSet{}).Where("workout_session_id = ? AND position > ?", session.ID, set.Position).
+		UpdateColumn("position", gorm.Expr("position - 1")).Error; err != nil {
+		tx.Rollback()
+		apperror.Abort(c, apperror.Database(err, "Failed to delete set"))
+		return
+	}
+	records, detectErr := detectRecords(tx, session, set.ExerciseId)
+	if detectErr != nil {
+		tx.Rollback()
+		apperror.Abort(c, detectErr)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to delete set"))
+		return
+	}
+	countRecords(records)
+
+	session, lookupErr = findSession(db, userId, session.ID)
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	units.FromSI(system, &session)
+	c.JSON(http.StatusOK, gin.H{"message": "Set deleted successfully", "data": session})
+}
+
+// @Tags Workout
+// @Summary Finish a workout session
+// @Description Finish a workout session of the authenticated user that is in progress. When the session was started from a schedule, the schedule is marked completed at the time the session finished. The progression rules of the plan the session follows then move the loads of the plan for its next scheduled workout, each change being listed with its explanation in the adjustments of the session.
+// @Param id path int true "Session ID"
+// @Param session body FinishSession false "Notes replacing those of the session"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.WorkoutSession
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/sessions/{id}/finish [post]
+func FinishWorkoutSession(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	session, lookupErr := openSession(db, userId, c.Param("id"))
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	// The body is optional, and chunked requests do not announce its length.
+	input := FinishSession{Notes: session.Notes}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		apperror.Abort(c, apperror.BadRequest("invalid_request_body", "Invalid request body"))
+		return
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := validation.Decode(validation.Context(c), body, &input); err != nil {
+			apperror.Abort(c, err)
+			return
+		}
+	}
+
+	tx := db.Begin()
+	completed, finishErr := finishSession(tx, &session, input)
+	if finishErr != nil {
+		tx.Rollback()
+		apperror.Abort(c, finishErr)
+		return
+	}
+	adjustments, progressErr := progressPlan(tx, session, system)
+	if progressErr != nil {
+		tx.Rollback()
+		apperror.Abort(c, progressErr)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to finish session"))
+		return
+	}
+	metrics.SessionsFinished.Inc()
+	if completed {
+		metrics.SchedulesCompleted.Inc()
+	}
+	countAdjustments(adjustments)
+
+	session, lookupErr = findSession(db, userId, session.ID)
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
+		return
+	}
+	units.FromSI(system, &session)
+	c.JSON(http.StatusOK, gin.H{"message": "Session finished successfully", "data": session})
+}
+
+// finishSession marks session finished and completes the schedule it was
+// started from, reporting whether it did. db should be a transaction.
+func finishSession(db *gorm.DB, session *model.WorkoutSession, input FinishSession) (bool, *apperror.Error) {
+	finishedAt := gorm.NowFunc()
+	result := db.Model(&model.WorkoutSession{}).Where("id = ? AND status = ?", session.ID, model.SessionInProgress).
+		Updates(map[string]interface{}{"status": model.SessionFinished, "finished_at": finishedAt, "notes": input.Notes})
+	if result.Error != nil {
+		return false, apperror.Database(result.Error, "Failed to finish session")
+	}
+	if result.RowsAffected == 0 {
+		return false, sessionFinished()
+	}
+	session.Status = model.SessionFinished
+	session.FinishedAt = &finishedAt
+
+	if session.WorkoutScheduleId == nil {
+		return false, nil
+	}
+	schedule, lookupErr := findSchedule(db, session.UserId, *session.WorkoutScheduleId)
+	if lookupErr != nil {
+		if lookupErr.Status == http.StatusNotFound {
+			return false, nil
+		}
+		return false, lookupErr
+	}
+	if schedule.Status == model.StatusCompleted {
+		return false, nil
+	}
+	changes := editableSchedule(schedule)
+	changes.Status = model.StatusCompleted
+	changes.CompletedDate = finishedAt
+	if err := saveSchedule(db, &schedule, changes, ""); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// findSession loads one of the user's sessions with its sets, the personal
+// records they set, the plan it follows and the changes it made to the plan.
+func findSession(db *gorm.DB, userId int64, id interface{}) (model.WorkoutSession, *apperror.Error) {
+	var session model.WorkoutSession
+	byPosition := func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}
+	err := db.Preload("Sets", byPosition).Preload("Sets.Records").
+		Preload("Records", "workout_session_set_id IS NULL").Preload("Adjustments").
+		Preload("WorkoutPlan").Preload("WorkoutPlan.Exercises", byPosition).
+		Preload("WorkoutPlan.Exercises.Prescriptions", byPosition).Preload("WorkoutPlan.Exercises.Progression").
+		Preload("WorkoutPlan.Groups").
+		First(&session, map[string]interface{}{"id": id, "user_id": userId}).Error
+	if err != nil {
+		return session, apperror.Lookup(err, "session_not_found", "Workout session not found")
+	}
+	return session, nil
+}
+
+// openSession loads one of the user's sessions, failing if it is finished.
+func openSession(db *gorm.DB, userId int64, id interface{}) (model.WorkoutSession, *apperror.Error) {
+	session, err := findSession(db, userId, id)
+	if err != nil {
+		return session, err
+	}
+	if session.Status != model.SessionInProgress {
+		return session, sessionFinished()
+	}
+	return session, nil
+}
+
+// lockSession locks the row of session until the end of tx, by touching it,
+// so that sets logged or deleted at the same time are numbered one after
+// another.
+func lockSession(tx *gorm.DB, session model.WorkoutSession) error {
+	return tx.Model(&model.WorkoutSession{}).Where("id = ?", session.ID).UpdateColumn("updated_at", gorm.NowFunc()).Error
+}
+
+func sessionFinished() *apperror.Error {
+	return apperror.Conflict("session_finished", "The session is finished and can no longer be changed")
+}
+
+// findSessionSet returns the set of session with the given id.
+func findSessionSet(session model.WorkoutSession, id string) (model.WorkoutSessionSet, *apperror.Error) {
+	setId, err := strconv.ParseUint(id, 10, 64)
+	if err == nil {
+		for _, set := range session.Sets {
+			if uint64(set.ID) == setId {
+				return set, nil
+			}
+		}
+	}
+	return model.WorkoutSessionSet{}, apperror.NotFound("session_set_not_found", "Set not found in this session")
+}
+
+// checkLoggedSet reports a set missing the measures of its exercise, or
+// linked to an exercise that is not part of the session's plan.
+func checkLoggedSet(db *gorm.DB, session model.WorkoutSession, input LoggedSet) *apperror.Error {
+	kinds, err := measurements(db, input.ExerciseId)
+	if err != nil {
+		return err
+	}
+	fields := checkMeasures(kinds[input.ExerciseId], "", "reps", input.Reps, false, input.Cardio)
+	if input.WorkoutPlanExerciseId != 0 && !inPlan(session.WorkoutPlan, input.WorkoutPlanExerciseId) {
+		fields = append(fields, apperror.FieldError{
+			Field:   "workout_plan_exercise_id",
+			Code:    "plan_exercise",
+			Message: "must reference an exercise of the session's plan",
+		})
+	}
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
+
+// inPlan reports whether the plan has an exercise with the given entry id.
+func inPlan(workout *model.WorkoutPlan, entryId int64) bool {
+	if workout == nil {
+		return false
+	}
+	for _, exercise := range workout.Exercises {
+		if int64(exercise.ID) == entryId {
+			return true
+		}
+	}
+	return false
+}
+
+func newSessionSet(input LoggedSet) model.WorkoutSessionSet {
+	set := model.WorkoutSessionSet{
+		ExerciseId: input.ExerciseId,
+		Reps:       input.Reps,
+		Weight:     input.Weight,
+		RPE:        input.RPE,
+		Notes:      input.Notes,
+		Cardio:     newCardio(input.Cardio),
+	}
+	if input.WorkoutPlanExerciseId != 0 {
+		set.WorkoutPlanExerciseId = &input.WorkoutPlanExerciseId
+	}
+	return set
+}
+
+// editableSessionSet returns the fields of set a client may change.
+func editableSessionSet(set model.WorkoutSessionSet) LoggedSet {
+	input := LoggedSet{
+		ExerciseId: set.ExerciseId,
+		Reps:       set.Reps,
+		Weight:     set.Weight,
+		RPE:        set.RPE,
+		Notes:      set.Notes,
+		Cardio:     editableCardio(set.Cardio),
+	}
+	if set.WorkoutPlanExerciseId != nil {
+		input.WorkoutPlanExerciseId = *set.WorkoutPlanExerciseId
+	}
+	return input
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"net/http"
+	"workout_tracker/internal/config"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/etag"
+	"workout_tracker/pkg/pagination"
+	"workout_tracker/pkg/units"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+var trashSorts = pagination.Sortable{"deleted_at": "deleted_at", "name": "name"}
+
+// @Tags Workout
+// @Summary Get deleted workout plans
+// @Description Get a page of the authenticated user's deleted workout plans. They can be restored until they are purged, TRASH_RETENTION_DAYS after deletion.
+// @Param limit query int false "Page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "deleted_at or name, prefixed with - for descending" default(-deleted_at)
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.WorkoutPlan
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/trash [get]
+func GetTrash(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	var query pagination.Params
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	page, pageErr := pagination.New(query, trashSorts, "-deleted_at")
+	if pageErr != nil {
+		apperror.Abort(c, pageErr)
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	db = withExercises(db).Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userId)
+	workouts, meta, err := pagination.Find[model.WorkoutPlan](db, page)
+	if err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to retrieve deleted workouts"))
+		return
+	}
+	units.FromSI(system, &workouts)
+	c.JSON(http.StatusOK, gin.H{"message": "Deleted workouts retrieved successfully", "data": workouts, "pagination": meta})
+}
+
+// @Tags Workout
+// @Summary Restore a deleted workout plan
+// @Description Restore a workout plan of the authenticated user from the trash, together with the schedules that were deleted with it.
+// @Param id path int true "Workout ID"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.WorkoutPlan
+// @Header 200 {string} ETag "New version of the plan"
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
+// @Router /workouts/{id}/restore [post]
+func RestoreWorkout(c *gin.Context) {
+	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+	if err != nil {
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+	var workout model.WorkoutPlan
+	if err := db.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", c.Param("id"), userId).First(&workout).Error; err != nil {
+		apperror.Abort(c, apperror.Lookup(err, "workout_not_in_trash", "Deleted workout plan not found"))
+		return
+	}
+
+	tx := db.Begin()
+	if err := restoreWorkout(tx, &workout); err != nil {
+		tx.Rollback()
+		apperror.Abort(c, err)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to restore workout plan"))
+		return
+	}
+	etag.Set(c, workout.Version)
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusOK, gin.H{"message": "Workout plan restored successfully", "data": workout})
+}
+
+// restoreWorkout takes workout and the schedules deleted along with it out
+// of the trash. workout is reloaded on success.
+func restoreWorkout(db *gorm.DB, workout *model.WorkoutPlan) *apperror.Error {
+	restore := map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")}
+	if err := db.Unscoped().Model(&model.WorkoutSchedule{}).
+		Where("workout_plan_id = ? AND deleted_at = (SELECT deleted_at FROM workout_plans WHERE id = ?)", workout.ID, workout.ID).
+		Updates(restore).Error; err != nil {
+		return apperror.Database(err, "Failed to restore workout schedules")
+	}
+
+	result := db.Unscoped().Model(&model.WorkoutPlan{}).Where("id = ? AND version = ?", workout.ID, workout.Version).Updates(restore)
+	if result.Error != nil {
+		return apperror.Database(result.Error, "Failed to restore workout plan")
+	}
+	if result.RowsAffected == 0 {
+		return etag.Stale("")
+	}
+	if err := withExercises(db).First(workout, workout.ID).Error; err != nil {
+		return apperror.Database(err, "Failed to retrieve restored workout plan")
+	}
+	return nil
+}
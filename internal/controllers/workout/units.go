@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	userModel "workout_tracker/internal/model/user"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/units"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+// unitSystem returns the units a request is in and its response is given
+// in: those of the unit query parameter if set, otherwise the user's
+// preferred units. They are kept in c for the validators capping loads and
+// distances.
+func unitSystem(c *gin.Context, db *gorm.DB, userId int64) (units.System, *apperror.Error) {
+	if unit, ok := c.GetQuery("unit"); ok {
+		system := units.System(unit)
+		if !system.Valid() {
+			return "", apperror.Validation(apperror.FieldError{
+				Field:   "unit",
+				Code:    "oneof",
+				Message: "must be one of: metric, imperial",
+			})
+		}
+		c.Set(validation.UnitSystemKey, system)
+		return system, nil
+	}
+	var user userModel.User
+	if err := db.Select("preferred_units").First(&user, userId).Error; err != nil {
+		return "", apperror.Lookup(err, "user_not_found", "User not found")
+	}
+	system := units.System(user.PreferredUnits)
+	c.Set(validation.UnitSystemKey, system)
+	return system, nil
+}
+
+// copyDecimal returns a copy of *d, so that converting the units of one
+// value leaves the other alone.
+func copyDecimal(d *units.Decimal) *units.Decimal {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	return &c
+}
@@ -1,276 +1,485 @@
 package controllers
 
 import (
-	"log"
+	"fmt"
 	"net/http"
 	"workout_tracker/internal/config"
-	exeModel "workout_tracker/internal/model/exercise"
 	model "workout_tracker/internal/model/workout"
+	"workout_tracker/internal/validation"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/etag"
+	"workout_tracker/pkg/metrics"
+	"workout_tracker/pkg/pagination"
+	"workout_tracker/pkg/units"
 	"workout_tracker/pkg/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
 )
 
 type WorkoutPlan struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	ExerciseId  int64   `json:"exercise_id"`
-	Sets        int64   `json:"sets"`
-	Repetitions int64   `json:"repetitions"`
-	Weight      float32 `json:"weight"`
-	Order       int64   `json:"order"`
+	Name        string                `json:"name" binding:"required,max=255"`
+	Description string                `json:"description" binding:"max=255"`
+	Order       int64                 `json:"order" binding:"gte=0"`
+	Exercises   []WorkoutPlanExercise `json:"exercises" binding:"required,min=1,max=50,dive"`
+	Groups      []WorkoutPlanGroup    `json:"groups" binding:"omitempty,max=25,dive"`
 }
-type WorkoutPlanDetails struct {
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	ExerciseId  exeModel.Exercise `json:"exercise"`
-	Sets        int64             `json:"sets"`
-	Repetitions int64             `json:"repetitions"`
-	Weight      float32           `json:"weight"`
-	Order       int64             `json:"order"`
+
+// WorkoutPlanPatch holds the fields of a plan that PATCH /workouts/{id} can
+// change. Its exercises are changed through /workouts/{id}/exercises.
+type WorkoutPlanPatch struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description" binding:"max=255"`
+	Order       int64  `json:"order" binding:"gte=0"`
+}
+type WorkoutListQuery struct {
+	pagination.Params
+	ExerciseId int64 `form:"exercise_id" binding:"omitempty,gt=0"`
 }
 type WorkoutReport struct {
-	WorkoutName   string  `json:"workout_name"`
-	TotalReps     uint    `json:"total_reps"`
-	AvgWeight     float64 `json:"average_weight"`
-	TotalWorkouts int64   `json:"total_workouts"`
+	WorkoutPlanId  int64   `json:"workout_plan_id"`
+	WorkoutName    string  `json:"workout_name"`
+	TotalExercises int64   `json:"total_exercises"`
+	TotalSets      int64   `json:"total_sets"`
+	TotalReps      int64   `json:"total_reps"`
+	AvgWeight      float64 `json:"average_weight" unit:"load"`
 }
 
+var workoutSorts = pagination.Sortable{"created_at": "created_at", "name": "name", "order": "order"}
+
 // @Tags Workout
 // @Summary Get user workout plan
-// @Description Get the workout plan of the authenticated user
+// @Description Get a page of the workout plans of the authenticated user
+// @Param limit query int false "Page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "created_at, name or order, prefixed with - for descending" default(created_at)
+// @Param exercise_id query int false "Only plans that include this exercise"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
 // @Accept json
 // @Produce json
-// @Success 200 {object} WorkoutPlan
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {array} model.WorkoutPlan
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts [get]
 func GetMyWorkouts(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
-	var workouts []model.WorkoutPlan
-	if err := config.GetDB().Find(&workouts, map[string]interface{}{"user_id": userId}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve workouts"})
+	var query WorkoutListQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-	if len(workouts) == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No workouts found"})
+	page, pageErr := pagination.New(query.Params, workoutSorts, "created_at")
+	if pageErr != nil {
+		apperror.Abort(c, pageErr)
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
+		return
+	}
+
+	db = withExercises(db).Where("user_id = ?", userId)
+	if query.ExerciseId != 0 {
+		db = db.Where("id IN (SELECT workout_plan_id FROM workout_plan_exercises WHERE exercise_id = ?)", query.ExerciseId)
+	}
+	workouts, meta, err := pagination.Find[model.WorkoutPlan](db, page)
+	if err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to retrieve workouts"))
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "All workouts retrieved successfully", "data": workouts})
+	units.FromSI(system, &workouts)
+	c.JSON(http.StatusOK, gin.H{"message": "All workouts retrieved successfully", "data": workouts, "pagination": meta})
 }
 
 // @Tags Workout
 // @Summary Get user workout plan by id
-// @Description Get the workout plan of the authenticated user by id
+// @Description Get the workout plan of the authenticated user by id, with the details of each of its exercises. Send the ETag of a previous response in If-None-Match to get 304 Not Modified while the plan and the units it is shown in are unchanged.
 // @Param id path int true "Workout ID"
+// @Param If-None-Match header string false "ETag of the cached plan"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
 // @Accept json
 // @Produce json
-// @Success 200 {object} WorkoutPlan
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} model.WorkoutPlan
+// @Header 200 {string} ETag "Version of the plan and the units it is shown in"
+// @Success 304
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts/{id} [get]
 func GetWorkoutByID(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
 	workoutId := c.Params.ByName("id")
 	if workoutId == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Workout Id is required!"})
+		apperror.Abort(c, apperror.BadRequest("workout_id_required", "Workout Id is required"))
 		return
 	}
 
-	var workout model.WorkoutPlan
-	if err := config.GetDB().First(&workout, map[string]interface{}{"ID": workoutId, "user_id": userId}).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Workout plan not found"})
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
 		return
 	}
 
-	var exercise exeModel.Exercise
-	if err := config.GetDB().First(&exercise, workout.ExerciseId).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Exercise not found"})
+	var workout model.WorkoutPlan
+	if err := withExercises(db).Preload("Exercises.Exercise").First(&workout, map[string]interface{}{"id": workoutId, "user_id": userId}).Error; err != nil {
+		apperror.Abort(c, apperror.Lookup(err, "workout_not_found", "Workout plan not found"))
 		return
 	}
-
-	response := WorkoutPlanDetails{
-		Name:        workout.Name,
-		Description: workout.Description,
-		ExerciseId:  exercise,
-		Sets:        workout.Sets,
-		Repetitions: workout.Repetitions,
-		Weight:      workout.Weight,
-		Order:       workout.Order,
+	if etag.NotModifiedTag(c, etag.ForRepresentation(workout.Version, string(system))) {
+		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Workout retrieved successfully", "data": response})
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusOK, gin.H{"message": "Workout retrieved successfully", "data": workout})
 }
 
 // @Tags Workout
 // @Summary Create user workout plan
 // @Description Create a new workout plan for the authenticated user
 // @Param workout body WorkoutPlan true "Workout"
+// @Param Idempotency-Key header string false "Unique key that makes retries of this request safe"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
 // @Accept json
 // @Produce json
-// @Success 201 {object} WorkoutPlan
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 201 {object} model.WorkoutPlan
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts [post]
 func CreateWorkout(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
 		return
 	}
 
-	var reqBody model.WorkoutPlan
-	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	var input WorkoutPlan
+	if err := validation.BindJSON(c, &input); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
+	units.ToSI(system, &input)
 
-	reqBody.UserId = userId
-	if err := config.GetDB().Create(&reqBody).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create workout"})
+	tx := db.Begin()
+	reqBody, createErr := createWorkout(tx, userId, input)
+	if createErr != nil {
+		tx.Rollback()
+		apperror.Abort(c, createErr)
 		return
 	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to create workout"))
+		return
+	}
+	metrics.WorkoutsCreated.Inc()
+	units.FromSI(system, &reqBody)
 	c.JSON(http.StatusCreated, gin.H{"message": "Workout created successfully", "data": reqBody})
 }
 
 // @Tags Workout
 // @Summary Update user workout plan
-// @Description Update a workout plan for the authenticated user
+// @Description Update a workout plan for the authenticated user with a JSON Merge Patch (RFC 7396), or a JSON Patch (RFC 6902) when sent as application/json-patch+json. Only the fields of WorkoutPlanPatch can be changed; exercises are changed through /workouts/{id}/exercises. Send the plan's ETag in If-Match to only apply the patch if nobody changed the plan since.
 // @Param id path int true "Workout ID"
-// @Param workout body WorkoutPlan true "Workout"
+// @Param If-Match header string false "ETag of the plan being patched"
+// @Param workout body WorkoutPlanPatch true "Merge patch with the fields to change"
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
 // @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
 // @Produce json
-// @Success 202 {object} WorkoutPlan
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 202 {object} model.WorkoutPlan
+// @Header 202 {string} ETag "New version of the plan"
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 415 {object} apperror.Error
+// @Failure 422 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts/{id} [patch]
 func UpdateWorkout(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
 	workoutId, ok := c.Params.Get("id")
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Workout Id is required!"})
+		apperror.Abort(c, apperror.BadRequest("workout_id_required", "Workout Id is required"))
 		return
 	}
 
-	var reqBody map[string]interface{}
-	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
 		return
 	}
 
-	delete(reqBody, "user_id")
-	delete(reqBody, "id")
-	if len(reqBody) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No valid fields to update"})
+	workout, lookupErr := findWorkout(db, userId, workoutId)
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
 		return
 	}
-
-	result := config.GetDB().Model(&model.WorkoutPlan{}).Where(map[string]interface{}{"ID": workoutId, "user_id": userId}).Updates(reqBody)
-	if result.Error != nil {
-		log.Printf("Database error updating workout: %v", result.Error)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update workout plan"})
+	if err := etag.IfMatch(c, workout.Version); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-	if result.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Workout plan not found or not authorized"})
+
+	input := editableWorkout(workout)
+	if err := validation.BindPatch(c, &input); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-
-	var updatedWorkout model.WorkoutPlan
-	if err := config.GetDB().First(&updatedWorkout, workoutId).Error; err != nil {
-		log.Printf("Error fetching updated workout: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve updated workout plan"})
+	if err := saveWorkout(db, &workout, input, c.GetHeader("If-Match")); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-	c.JSON(http.StatusAccepted, gin.H{"message": "Workout plan updated successfully", "data": updatedWorkout})
+	etag.Set(c, workout.Version)
+	units.FromSI(system, &workout)
+	c.JSON(http.StatusAccepted, gin.H{"message": "Workout plan updated successfully", "data": workout})
 }
 
 // @Tags Workout
 // @Summary Delete user workout plan by id
-// @Description Delete the workout plan of the authenticated user by id
+// @Description Move the workout plan of the authenticated user, and its schedules, to the trash. Send the plan's ETag in If-Match to only delete it if nobody changed it since.
 // @Param id path int true "Workout ID"
+// @Param If-Match header string false "ETag of the plan being deleted"
 // @Accept json
 // @Produce json
 // @Success 204 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 404 {object} apperror.Error
+// @Failure 409 {object} apperror.Error
+// @Failure 412 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts/{id} [delete]
 func DeleteWorkout(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
 		return
 	}
 
 	workoutId, ok := c.Params.Get("id")
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Workout Id is required!"})
+		apperror.Abort(c, apperror.BadRequest("workout_id_required", "Workout Id is required"))
 		return
 	}
 
-	result := config.GetDB().Delete(&model.WorkoutPlan{}, map[string]interface{}{"ID": workoutId, "user_id": userId})
-	if result.Error != nil {
-		log.Printf("Database error deleting workout: %v", result.Error)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete workout plan"})
+	db := config.GetDBContext(c.Request.Context())
+	workout, lookupErr := findWorkout(db, userId, workoutId)
+	if lookupErr != nil {
+		apperror.Abort(c, lookupErr)
 		return
 	}
-	if result.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Workout plan not found or not authorized"})
+	if err := etag.IfMatch(c, workout.Version); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	tx := db.Begin()
+	if err := deleteWorkout(tx, &workout, c.GetHeader("If-Match")); err != nil {
+		tx.Rollback()
+		apperror.Abort(c, err)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		apperror.Abort(c, apperror.Database(err, "Failed to delete workout plan"))
 		return
 	}
 
 	c.JSON(http.StatusNoContent, gin.H{"message": "Workout plan deleted"})
 }
 
+// findWorkout loads one of the user's workout plans.
+func findWorkout(db *gorm.DB, userId int64, id interface{}) (model.WorkoutPlan, *apperror.Error) {
+	var workout model.WorkoutPlan
+	if err := withExercises(db).First(&workout, map[string]interface{}{"id": id, "user_id": userId}).Error; err != nil {
+		return workout, apperror.Lookup(err, "workout_not_found", "Workout plan not found or not authorized")
+	}
+	return workout, nil
+}
+
+// withExercises makes db load the exercises of workout plans, and their set
+// prescriptions and progression rules, in order along with the groups of the
+// exercises.
+func withExercises(db *gorm.DB) *gorm.DB {
+	byPosition := func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}
+	byID := func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}
+	return db.Preload("Exercises", byPosition).Preload("Exercises.Prescriptions", byPosition).
+		Preload("Exercises.Progression").Preload("Groups", byID)
+}
+
+func newWorkoutPlan(userId int64, input WorkoutPlan) model.WorkoutPlan {
+	workout := model.WorkoutPlan{
+		Name:        input.Name,
+		Description: input.Description,
+		UserId:      userId,
+		Order:       input.Order,
+	}
+	for i, exercise := range input.Exercises {
+		workout.Exercises = append(workout.Exercises, newPlanExercise(exercise, int64(i+1)))
+	}
+	return workout
+}
+
+// createWorkout creates a plan from input with its exercises and groups.
+// db should be a transaction.
+func createWorkout(db *gorm.DB, userId int64, input WorkoutPlan) (model.WorkoutPlan, *apperror.Error) {
+	exercisePath := func(i int) string { return fmt.Sprintf("exercises[%d].", i) }
+	if err := checkPlanExercisesMeasures(db, exercisePath, input.Exercises...); err != nil {
+		return model.WorkoutPlan{}, err
+	}
+	spans := make([]groupSpan, len(input.Groups))
+	for i, group := range input.Groups {
+		spans[i] = groupSpan{field: fmt.Sprintf("groups[%d].exercises", i), kind: group.Type, positions: group.Exercises}
+	}
+	if fields := checkGroupSpans(spans, int64(len(input.Exercises))); len(fields) > 0 {
+		return model.WorkoutPlan{}, apperror.Validation(fields...)
+	}
+
+	workout := newWorkoutPlan(userId, input)
+	if err := db.Create(&workout).Error; err != nil {
+		return workout, apperror.Database(err, "Failed to create workout")
+	}
+	if len(input.Groups) == 0 {
+		return workout, nil
+	}
+	for _, group := range input.Groups {
+		if err := createGroup(db, workout.ID, group); err != nil {
+			return workout, err
+		}
+	}
+	if err := withExercises(db).First(&workout, workout.ID).Error; err != nil {
+		return workout, apperror.Database(err, "Failed to retrieve created workout")
+	}
+	return workout, nil
+}
+
+// editableWorkout returns the fields of workout a client may change.
+func editableWorkout(workout model.WorkoutPlan) WorkoutPlanPatch {
+	return WorkoutPlanPatch{
+		Name:        workout.Name,
+		Description: workout.Description,
+		Order:       workout.Order,
+	}
+}
+
+// saveWorkout writes input over workout and bumps its version, failing if
+// the plan changed since it was read. workout is reloaded on success.
+func saveWorkout(db *gorm.DB, workout *model.WorkoutPlan, input WorkoutPlanPatch, ifMatch string) *apperror.Error {
+	updates := map[string]interface{}{
+		"name":        input.Name,
+		"description": input.Description,
+		"order":       input.Order,
+		"version":     gorm.Expr("version + 1"),
+	}
+	result := db.Model(&model.WorkoutPlan{}).Where("id = ? AND version = ?", workout.ID, workout.Version).Updates(updates)
+	if result.Error != nil {
+		return apperror.Database(result.Error, "Failed to update workout plan")
+	}
+	if result.RowsAffected == 0 {
+		return etag.Stale(ifMatch)
+	}
+	if err := withExercises(db).First(workout, workout.ID).Error; err != nil {
+		return apperror.Database(err, "Failed to retrieve updated workout plan")
+	}
+	return nil
+}
+
+// deleteWorkout moves workout to the trash together with its schedules,
+// failing if the plan changed since it was read. The schedules get the same
+// deleted_at as the plan, which is how restoreWorkout tells them apart from
+// schedules deleted on their own. db should be a transaction.
+func deleteWorkout(db *gorm.DB, workout *model.WorkoutPlan, ifMatch string) *apperror.Error {
+	deletedAt := gorm.NowFunc()
+	result := db.Model(&model.WorkoutPlan{}).Where("id = ? AND version = ?", workout.ID, workout.Version).
+		Updates(map[string]interface{}{"deleted_at": deletedAt, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return apperror.Database(result.Error, "Failed to delete workout plan")
+	}
+	if result.RowsAffected == 0 {
+		return etag.Stale(ifMatch)
+	}
+	if err := db.Model(&model.WorkoutSchedule{}).Where("workout_plan_id = ?", workout.ID).
+		Updates(map[string]interface{}{"deleted_at": deletedAt, "version": gorm.Expr("version + 1")}).Error; err != nil {
+		return apperror.Database(err, "Failed to delete workout schedules")
+	}
+	return nil
+}
+
 // @Tags Workout
 // @Summary Get user workout reports
-// @Description Get the workout reports of the authenticated user
+// @Description Get a report per workout plan of the authenticated user with the number of exercises, the sets and repetitions they prescribe, and their average weight
+// @Param unit query string false "Units of loads and distances, metric or imperial; defaults to the user's preferred units"
 // @Accept json
 // @Produce json
-// @Success 200 {object} WorkoutReport
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {array} WorkoutReport
+// @Failure 400 {object} apperror.Error
+// @Failure 401 {object} apperror.Error
+// @Failure 500 {object} apperror.Error
 // @Router /workouts/reports [get]
 func GenerateWorkoutReport(c *gin.Context) {
 	userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.Unauthorized())
+		return
+	}
+
+	db := config.GetDBContext(c.Request.Context())
+	system, unitErr := unitSystem(c, db, userId)
+	if unitErr != nil {
+		apperror.Abort(c, unitErr)
 		return
 	}
 
 	var report []WorkoutReport
-	selectStatement := "name as workout_name, SUM(repetitions) as total_reps, AVG(weight) as avg_weight, COUNT(*) as total_workouts"
-	result := config.GetDB().Model(&WorkoutPlan{}).Select(selectStatement).Where("user_id = ?", userId).Group("name").Scan(&report)
+	selectStatement := "workout_plans.id as workout_plan_id, workout_plans.name as workout_name, COUNT(*) as total_exercises, " +
+		"SUM(e.sets) as total_sets, SUM(e.sets * e.repetitions) as total_reps, AVG(e.weight) as avg_weight"
+	result := db.Model(&model.WorkoutPlan{}).Select(selectStatement).
+		Joins("JOIN workout_plan_exercises e ON e.workout_plan_id = workout_plans.id").
+		Where("workout_plans.user_id = ?", userId).
+		Group("workout_plans.id, workout_plans.name").Order("workout_plans.id").Scan(&report)
 	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate report"})
+		apperror.Abort(c, apperror.Database(result.Error, "Failed to generate report"))
 		return
 	}
 	if len(report) == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No workout data found for this user"})
+		apperror.Abort(c, apperror.NotFound("report_not_found", "No workout data found for this user"))
 		return
 	}
+	units.FromSI(system, &report)
 	c.JSON(http.StatusOK, gin.H{"message": "Workout report generated successfully", "data": report})
 }
@@ -0,0 +1,16 @@
+// Package integration tests the API end to end against a database migrated
+// with the embedded migrations. It covers the queries that differ between
+// dialects: the workout report, personal records, one-rep maxes and training
+// programs.
+//
+// The tests run on a temporary SQLite database as part of go test ./....
+// Set INTEGRATION_DATABASE_URL to a database URL, in the format of
+// DATABASE_URL, to run them against PostgreSQL or MySQL instead:
+//
+//	make db-up
+//	make test-postgres
+//	make test-mysql
+//
+// The database is migrated up and every test works as a user of its own, so
+// a database used for development can be reused.
+package integration
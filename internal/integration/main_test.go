@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	routes "workout_tracker/api"
+	"workout_tracker/internal/config"
+	"workout_tracker/internal/migrate"
+	exerciseModel "workout_tracker/internal/model/exercise"
+	userModel "workout_tracker/internal/model/user"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var router *gin.Engine
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	url := os.Getenv("INTEGRATION_DATABASE_URL")
+	if url == "" {
+		dir, err := os.MkdirTemp("", "kinetic-integration")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer os.RemoveAll(dir)
+		url = "sqlite://" + filepath.Join(dir, "kinetic.db")
+	}
+	os.Setenv("DATABASE_URL", url)
+	defer config.CloseDB()
+
+	migrator, err := migrate.New(config.GetDB().DB(), config.Dialect())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		return 1
+	}
+
+	gin.SetMode(gin.TestMode)
+	router = gin.New()
+	routes.RegisterRoutes(router.Group("/api/v1"))
+	return m.Run()
+}
+
+// client calls the API as a user created for the test.
+type client struct {
+	t     *testing.T
+	token string
+}
+
+func newClient(t *testing.T) *client {
+	t.Helper()
+	user := userModel.User{Email: uniqueName(t) + "@example.com", FirstName: "Test", IsVerified: true}
+	if err := config.GetDB().Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	token, err := utils.SignJWTToken(int64(user.ID), user.Email)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return &client{t: t, token: token}
+}
+
+// call sends body as JSON to path under /api/v1, fails the test unless the
+// response has the wanted status, and decodes the data of the response into
+// data when it is not nil.
+func (c *client) call(method, path string, body interface{}, want int, data interface{}) {
+	c.t.Helper()
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			c.t.Fatalf("encode %s %s: %v", method, path, err)
+		}
+	}
+	rec := c.send(method, path, &payload)
+
+	if rec.Code != want {
+		c.t.Fatalf("%s %s = %d, want %d: %s", method, path, rec.Code, want, rec.Body)
+	}
+	if data == nil {
+		return
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		c.t.Fatalf("decode %s %s: %v", method, path, err)
+	}
+	if err := json.Unmarshal(envelope.Data, data); err != nil {
+		c.t.Fatalf("decode data of %s %s: %v", method, path, err)
+	}
+}
+
+// send sends body as JSON to path under /api/v1 and returns the response.
+// Unlike call, it can be used from other goroutines than the test's.
+func (c *client) send(method, path string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1"+path, body)
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// newExercise creates an exercise measured by reps and load.
+func newExercise(t *testing.T) int64 {
+	t.Helper()
+	exercise := exerciseModel.Exercise{Name: uniqueName(t), Measurement: exerciseModel.MeasureRepsLoad}
+	if err := config.GetDB().Create(&exercise).Error; err != nil {
+		t.Fatalf("create exercise: %v", err)
+	}
+	return int64(exercise.ID)
+}
+
+// uniqueName returns a name for rows of the test that no other run has used,
+// so that a database can be shared between runs.
+func uniqueName(t *testing.T) string {
+	return fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano())
+}
+
+type idOnly struct {
+	ID int64 `json:"ID"`
+}
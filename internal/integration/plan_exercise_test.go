@@ -0,0 +1,39 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPatchPrescribedExercise(t *testing.T) {
+	c := newClient(t)
+	squat := newExercise(t)
+	created := c.newPlan(map[string]interface{}{
+		"exercise_id": squat,
+		"prescriptions": []map[string]interface{}{
+			{"type": "warmup", "reps": 5, "weight": 60},
+			{"type": "working", "reps": 5, "weight": 100},
+		},
+	})
+	path := fmt.Sprintf("/workouts/%d/exercises/%d", created.ID, created.Exercises[0].ID)
+
+	tests := []struct {
+		name  string
+		patch map[string]interface{}
+		want  int
+	}{
+		{"sets", map[string]interface{}{"sets": 5}, http.StatusUnprocessableEntity},
+		{"repetitions with new prescriptions", map[string]interface{}{
+			"repetitions":   8,
+			"prescriptions": []map[string]interface{}{{"type": "working", "reps": 8, "weight": 90}},
+		}, http.StatusUnprocessableEntity},
+		{"notes", map[string]interface{}{"notes": "Pause at the bottom"}, http.StatusOK},
+		{"sets without prescriptions", map[string]interface{}{"prescriptions": nil, "sets": 5}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.call(http.MethodPatch, path, tt.patch, tt.want, nil)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+type planExercise struct {
+	ID            int64   `json:"id"`
+	Sets          int64   `json:"sets"`
+	Repetitions   int64   `json:"repetitions"`
+	Weight        float64 `json:"weight"`
+	Prescriptions []struct {
+		Weight *float64 `json:"weight"`
+	} `json:"prescriptions"`
+}
+
+type plan struct {
+	ID        int64          `json:"ID"`
+	Exercises []planExercise `json:"exercises"`
+}
+
+// newPlan creates a plan of the given exercises.
+func (c *client) newPlan(exercises ...map[string]interface{}) plan {
+	c.t.Helper()
+	var created plan
+	c.call(http.MethodPost, "/workouts", map[string]interface{}{"name": uniqueName(c.t), "exercises": exercises}, http.StatusCreated, &created)
+	return created
+}
+
+// logSession starts a session, logs sets of weight x reps on exercise in it
+// and finishes it.
+func (c *client) logSession(exercise int64, sets ...[2]float64) {
+	c.t.Helper()
+	var session idOnly
+	c.call(http.MethodPost, "/workouts/sessions", map[string]interface{}{}, http.StatusCreated, &session)
+	for _, set := range sets {
+		c.call(http.MethodPost, fmt.Sprintf("/workouts/sessions/%d/sets", session.ID),
+			map[string]interface{}{"exercise_id": exercise, "weight": set[0], "reps": set[1]}, http.StatusCreated, nil)
+	}
+	c.call(http.MethodPost, fmt.Sprintf("/workouts/sessions/%d/finish", session.ID), map[string]interface{}{}, http.StatusOK, nil)
+}
+
+func TestWorkoutReport(t *testing.T) {
+	c := newClient(t)
+	squat, bench := newExercise(t), newExercise(t)
+	created := c.newPlan(
+		map[string]interface{}{"exercise_id": squat, "sets": 3, "repetitions": 10, "weight": 100},
+		map[string]interface{}{"exercise_id": bench, "sets": 4, "repetitions": 8, "weight": 50},
+	)
+
+	var report []struct {
+		WorkoutPlanId  int64   `json:"workout_plan_id"`
+		TotalExercises int64   `json:"total_exercises"`
+		TotalSets      int64   `json:"total_sets"`
+		TotalReps      int64   `json:"total_reps"`
+		AverageWeight  float64 `json:"average_weight"`
+	}
+	c.call(http.MethodGet, "/workouts/reports", nil, http.StatusOK, &report)
+	if len(report) != 1 {
+		t.Fatalf("got %d report rows, want 1", len(report))
+	}
+	row := report[0]
+	if row.WorkoutPlanId != created.ID || row.TotalExercises != 2 || row.TotalSets != 7 || row.TotalReps != 62 || row.AverageWeight != 75 {
+		t.Errorf("report = %+v, want plan %d with 2 exercises, 7 sets, 62 reps and an average of 75 kg", row, created.ID)
+	}
+}
+
+func TestPersonalRecords(t *testing.T) {
+	c := newClient(t)
+	squat := newExercise(t)
+	c.logSession(squat, [2]float64{100, 5}, [2]float64{110, 3})
+	c.logSession(squat, [2]float64{105, 8})
+
+	type record struct {
+		Type         string  `json:"type"`
+		Weight       float64 `json:"weight"`
+		Reps         int64   `json:"reps"`
+		Estimated1RM float64 `json:"estimated_1rm"`
+	}
+	tests := []struct {
+		kind string
+		want record
+	}{
+		{"heaviest_weight", record{Type: "heaviest_weight", Weight: 110, Reps: 3}},
+		{"estimated_1rm", record{Type: "estimated_1rm", Weight: 105, Reps: 8, Estimated1RM: 133}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			var all, current []record
+			c.call(http.MethodGet, fmt.Sprintf("/records?exercise_id=%d&type=%s", squat, tt.kind), nil, http.StatusOK, &all)
+			c.call(http.MethodGet, fmt.Sprintf("/records?exercise_id=%d&type=%s&current=true", squat, tt.kind), nil, http.StatusOK, &current)
+			if len(current) != 1 || current[0] != tt.want {
+				t.Errorf("current records = %+v, want only %+v", current, tt.want)
+			}
+			if len(all) <= len(current) {
+				t.Errorf("got %d records in the history, want the beaten ones too", len(all))
+			}
+		})
+	}
+}
+
+func TestOneRepMaxes(t *testing.T) {
+	c := newClient(t)
+	squat := newExercise(t)
+	c.logSession(squat, [2]float64{100, 5}, [2]float64{105, 8})
+
+	type oneRepMax struct {
+		ExerciseId            int64    `json:"exercise_id"`
+		Estimated1RM          *float64 `json:"estimated_1rm"`
+		TrainingMax           *float64 `json:"training_max"`
+		TrainingMaxOverridden bool     `json:"training_max_overridden"`
+	}
+	var maxes []oneRepMax
+	c.call(http.MethodGet, "/one-rep-maxes", nil, http.StatusOK, &maxes)
+	if len(maxes) != 1 || maxes[0].ExerciseId != squat || maxes[0].Estimated1RM == nil || *maxes[0].Estimated1RM != 133 {
+		t.Fatalf("one-rep maxes = %+v, want 133 kg estimated on exercise %d", maxes, squat)
+	}
+
+	var overridden oneRepMax
+	c.call(http.MethodPut, fmt.Sprintf("/one-rep-maxes/%d/training-max", squat), map[string]interface{}{"training_max": 120}, http.StatusOK, &overridden)
+	if !overridden.TrainingMaxOverridden || *overridden.TrainingMax != 120 || *overridden.Estimated1RM != 133 {
+		t.Errorf("after setting the training max = %+v, want 120 kg overriding the 133 kg estimate", overridden)
+	}
+
+	// Percentages of the one-rep max resolve from the training max, rounded
+	// to 2.5 kg plates.
+	created := c.newPlan(map[string]interface{}{
+		"exercise_id": squat,
+		"prescriptions": []map[string]interface{}{
+			{"type": "working", "reps": 5, "percent_1rm": 75},
+			{"type": "working", "reps": 3, "percent_1rm": 86},
+		},
+	})
+	var schedule idOnly
+	c.call(http.MethodPost, "/workouts/schedules", map[string]interface{}{"workout_plan_id": created.ID, "scheduled_date": "2026-01-05T00:00:00Z"},
+		http.StatusCreated, &schedule)
+	var details struct {
+		WorkoutPlan plan `json:"workout_plan"`
+	}
+	c.call(http.MethodGet, fmt.Sprintf("/workouts/schedules/%d", schedule.ID), nil, http.StatusOK, &details)
+	var loads []float64
+	for _, set := range details.WorkoutPlan.Exercises[0].Prescriptions {
+		if set.Weight == nil {
+			t.Fatalf("prescription without a resolved weight in %+v", details.WorkoutPlan)
+		}
+		loads = append(loads, *set.Weight)
+	}
+	if fmt.Sprint(loads) != "[90 102.5]" {
+		t.Errorf("resolved loads = %v, want [90 102.5]", loads)
+	}
+}
+
+func TestProgramEnrollment(t *testing.T) {
+	c := newClient(t)
+	squat := newExercise(t)
+	created := c.newPlan(map[string]interface{}{"exercise_id": squat, "sets": 4, "repetitions": 5, "weight": 100})
+
+	var program idOnly
+	c.call(http.MethodPost, "/programs", map[string]interface{}{
+		"name": uniqueName(t),
+		"weeks": []map[string]interface{}{
+			{"intensity_percent": 100, "volume_percent": 100},
+			{"intensity_percent": 90, "volume_percent": 50, "notes": "Deload"},
+		},
+		"days": []map[string]interface{}{{"workout_plan_id": created.ID}},
+	}, http.StatusCreated, &program)
+
+	var enrollment struct {
+		Schedules []struct {
+			ID            int64  `json:"ID"`
+			ScheduledDate string `json:"scheduled_date"`
+			ProgramWeek   int64  `json:"program_week"`
+		} `json:"schedules"`
+	}
+	c.call(http.MethodPost, fmt.Sprintf("/programs/%d/enroll", program.ID),
+		map[string]interface{}{"start_date": "2026-01-05", "training_days": []string{"wed"}}, http.StatusCreated, &enrollment)
+	if len(enrollment.Schedules) != 2 {
+		t.Fatalf("got %d schedules, want one per week", len(enrollment.Schedules))
+	}
+
+	tests := []struct {
+		date   string
+		sets   int64
+		weight float64
+	}{
+		{"2026-01-07", 4, 100},
+		{"2026-01-14", 2, 90},
+	}
+	for i, tt := range tests {
+		schedule := enrollment.Schedules[i]
+		if schedule.ScheduledDate[:10] != tt.date || schedule.ProgramWeek != int64(i+1) {
+			t.Errorf("schedule %d on %s of week %d, want %s of week %d", i, schedule.ScheduledDate, schedule.ProgramWeek, tt.date, i+1)
+		}
+		var details struct {
+			WorkoutPlan plan `json:"workout_plan"`
+		}
+		c.call(http.MethodGet, fmt.Sprintf("/workouts/schedules/%d", schedule.ID), nil, http.StatusOK, &details)
+		exercise := details.WorkoutPlan.Exercises[0]
+		if exercise.Sets != tt.sets || exercise.Weight != tt.weight {
+			t.Errorf("week %d prescribes %d sets of %v kg, want %d of %v kg", i+1, exercise.Sets, exercise.Weight, tt.sets, tt.weight)
+		}
+	}
+
+	var programs []idOnly
+	c.call(http.MethodGet, "/programs", nil, http.StatusOK, &programs)
+	if len(programs) != 1 || programs[0].ID != program.ID {
+		t.Errorf("programs = %+v, want only %d", programs, program.ID)
+	}
+}
+
+func TestProgramWeekDays(t *testing.T) {
+	c := newClient(t)
+	squat, bench := newExercise(t), newExercise(t)
+	lower := c.newPlan(map[string]interface{}{"exercise_id": squat, "sets": 4, "repetitions": 5, "weight": 100})
+	upper := c.newPlan(map[string]interface{}{"exercise_id": bench, "sets": 3, "repetitions": 8, "weight": 60})
+
+	type program struct {
+		ID    int64 `json:"ID"`
+		Weeks []struct {
+			Days []struct {
+				WorkoutPlanId int64 `json:"workout_plan_id"`
+			} `json:"days"`
+		} `json:"weeks"`
+		Days []struct {
+			WorkoutPlanId int64 `json:"workout_plan_id"`
+		} `json:"days"`
+	}
+	var created program
+	c.call(http.MethodPost, "/programs", map[string]interface{}{
+		"name": uniqueName(t),
+		"weeks": []map[string]interface{}{
+			{},
+			{"days": []map[string]interface{}{{"workout_plan_id": upper.ID}, {"workout_plan_id": lower.ID}}},
+		},
+		"days": []map[string]interface{}{{"workout_plan_id": lower.ID}},
+	}, http.StatusCreated, &created)
+
+	// Renaming keeps the days of the second week.
+	var renamed program
+	c.call(http.MethodPatch, fmt.Sprintf("/programs/%d", created.ID), map[string]interface{}{"name": uniqueName(t)}, http.StatusAccepted, &renamed)
+	if len(renamed.Days) != 1 || len(renamed.Weeks[0].Days) != 0 || len(renamed.Weeks[1].Days) != 2 || renamed.Weeks[1].Days[0].WorkoutPlanId != upper.ID {
+		t.Fatalf("program = %+v, want 1 day of its own and 2 in the second week", renamed)
+	}
+
+	// The second week needs two training days.
+	path := fmt.Sprintf("/programs/%d/enroll", created.ID)
+	c.call(http.MethodPost, path, map[string]interface{}{"start_date": "2026-01-05", "training_days": []string{"mon"}}, http.StatusUnprocessableEntity, nil)
+
+	var enrollment struct {
+		Schedules []struct {
+			WorkoutPlanId int64  `json:"workout_plan_id"`
+			ScheduledDate string `json:"scheduled_date"`
+		} `json:"schedules"`
+	}
+	c.call(http.MethodPost, path, map[string]interface{}{"start_date": "2026-01-05", "training_days": []string{"mon", "thu"}}, http.StatusCreated, &enrollment)
+	var got []string
+	for _, schedule := range enrollment.Schedules {
+		got = append(got, fmt.Sprintf("%s %d", schedule.ScheduledDate[:10], schedule.WorkoutPlanId))
+	}
+	want := []string{
+		fmt.Sprintf("2026-01-05 %d", lower.ID),
+		fmt.Sprintf("2026-01-12 %d", upper.ID),
+		fmt.Sprintf("2026-01-15 %d", lower.ID),
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("schedules = %v, want %v", got, want)
+	}
+}
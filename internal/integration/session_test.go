@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type session struct {
+	ID    int64  `json:"ID"`
+	Notes string `json:"notes"`
+	Sets  []struct {
+		ID       int64 `json:"ID"`
+		Position int64 `json:"position"`
+	} `json:"sets"`
+}
+
+// checkPositions fails the test unless the sets of s are numbered 1 to n in
+// order.
+func checkPositions(t *testing.T, s session, n int) {
+	t.Helper()
+	if len(s.Sets) != n {
+		t.Fatalf("got %d sets, want %d", len(s.Sets), n)
+	}
+	for i, set := range s.Sets {
+		if set.Position != int64(i+1) {
+			t.Errorf("set %d has position %d, want %d", set.ID, set.Position, i+1)
+		}
+	}
+}
+
+func TestSessionSetPositions(t *testing.T) {
+	c := newClient(t)
+	squat := newExercise(t)
+	var started session
+	c.call(http.MethodPost, "/workouts/sessions", map[string]interface{}{}, http.StatusCreated, &started)
+	path := fmt.Sprintf("/workouts/sessions/%d", started.ID)
+
+	// Sets logged at the same time, as from two devices, get a position each.
+	const logged = 6
+	codes := make([]int, logged)
+	var wg sync.WaitGroup
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body := fmt.Sprintf(`{"exercise_id": %d, "weight": 100, "reps": %d}`, squat, i+1)
+			codes[i] = c.send(http.MethodPost, path+"/sets", strings.NewReader(body)).Code
+		}(i)
+	}
+	wg.Wait()
+	for i, code := range codes {
+		if code != http.StatusCreated {
+			t.Fatalf("logging set %d = %d, want %d", i+1, code, http.StatusCreated)
+		}
+	}
+	var current session
+	c.call(http.MethodGet, path, nil, http.StatusOK, &current)
+	checkPositions(t, current, logged)
+
+	// Deleting a set closes the gap it leaves.
+	c.call(http.MethodDelete, fmt.Sprintf("%s/sets/%d", path, current.Sets[1].ID), nil, http.StatusOK, &current)
+	checkPositions(t, current, logged-1)
+	c.call(http.MethodPost, path+"/sets", map[string]interface{}{"exercise_id": squat, "weight": 100, "reps": 5}, http.StatusCreated, &current)
+	checkPositions(t, current, logged)
+}
+
+func TestFinishSessionNotes(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		name string
+		body io.Reader
+		want string
+	}{
+		{"without a body", http.NoBody, "Started"},
+		{"with a body", strings.NewReader(`{"notes": "Felt strong"}`), "Felt strong"},
+		// A reader of unknown length is sent chunked, without a Content-Length.
+		{"with a chunked body", io.MultiReader(strings.NewReader(`{"notes": "Felt strong"}`)), "Felt strong"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var started session
+			c.call(http.MethodPost, "/workouts/sessions", map[string]interface{}{"notes": "Started"}, http.StatusCreated, &started)
+			path := fmt.Sprintf("/workouts/sessions/%d", started.ID)
+			if rec := c.send(http.MethodPost, path+"/finish", tt.body); rec.Code != http.StatusOK {
+				t.Fatalf("finishing = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			var finished session
+			c.call(http.MethodGet, path, nil, http.StatusOK, &finished)
+			if finished.Notes != tt.want {
+				t.Errorf("notes = %q, want %q", finished.Notes, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadCapInUnits(t *testing.T) {
+	c := newClient(t)
+	squat := newExercise(t)
+	var started session
+	c.call(http.MethodPost, "/workouts/sessions", map[string]interface{}{}, http.StatusCreated, &started)
+	path := fmt.Sprintf("/workouts/sessions/%d/sets", started.ID)
+
+	// Loads are capped at 2000 kg, which is 4409.25 lb.
+	tests := []struct {
+		unit    string
+		weight  float64
+		message string
+	}{
+		{"metric", 2000, ""},
+		{"metric", 2000.5, "must be at most 2000 kg"},
+		{"imperial", 4000, ""},
+		{"imperial", 4409.25, ""},
+		{"imperial", 4500, "must be at most 4409.25 lb"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v %s", tt.weight, tt.unit), func(t *testing.T) {
+			body := fmt.Sprintf(`{"exercise_id": %d, "weight": %v, "reps": 1}`, squat, tt.weight)
+			rec := c.send(http.MethodPost, path+"?unit="+tt.unit, strings.NewReader(body))
+			if tt.message == "" {
+				if rec.Code != http.StatusCreated {
+					t.Fatalf("logging = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+				}
+				return
+			}
+			var problem struct {
+				Errors []struct {
+					Field   string `json:"field"`
+					Message string `json:"message"`
+				} `json:"errors"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil || rec.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("logging = %d, want %d: %s", rec.Code, http.StatusUnprocessableEntity, rec.Body)
+			}
+			if len(problem.Errors) != 1 || problem.Errors[0].Field != "weight" || problem.Errors[0].Message != tt.message {
+				t.Errorf("errors = %+v, want weight %s", problem.Errors, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*/*.sql
+var embedded embed.FS
+
+// SourceDir is where `migrate create` writes new migration files, relative to
+// the repository root. Each dialect has its own subdirectory.
+const SourceDir = "internal/migrate/migrations"
+
+// Dialects lists the databases with a migrations subdirectory.
+var Dialects = []string{"mysql", "postgres", "sqlite3"}
+
+const (
+	tableName = "schema_migrations"
+	lockName  = "kinetic_core_migrate"
+	lockKey   = 7263746 // pg_advisory_lock key
+	lockWait  = 60      // seconds
+)
+
+var (
+	fileName    = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	migrateName = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Modified  bool       `json:"modified"`
+}
+
+type Migrator struct {
+	db         *sql.DB
+	dialect    string
+	migrations []Migration
+}
+
+// New returns a migrator for the embedded migrations of the given dialect.
+func New(db *sql.DB, dialect string) (*Migrator, error) {
+	sub, err := fs.Sub(embedded, "migrations/"+dialect)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := load(sub)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported dialect %q: %w", dialect, err)
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s is missing its up file", m.Version, m.Name)
+		}
+		sum := sha256.Sum256([]byte(m.Up))
+		m.Checksum = hex.EncodeToString(sum[:])
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+type applied struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// Up applies every pending migration in version order and returns the ones
+// that ran.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var ran []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(done); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			err := m.exec(ctx, conn, migration.Up,
+				m.bind("INSERT INTO "+tableName+" (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)"),
+				migration.Version, migration.Name, migration.Checksum, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			ran = append(ran, migration)
+		}
+		return nil
+	})
+	return ran, err
+}
+
+// Down rolls back the latest steps applied migrations and returns the ones
+// that were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+			err := m.exec(ctx, conn, migration.Down,
+				m.bind("DELETE FROM "+tableName+" WHERE version = ?"), migration.Version)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status reports every known migration and whether it has been applied. It
+// only reads, so readiness probes and database users without DDL rights can
+// call it; before the first migration, when there is no table of applied
+// migrations yet, every migration is pending.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	done := map[int64]applied{}
+	exists, err := m.tableExists(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if done, err = m.readApplied(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if row, ok := done[migration.Version]; ok {
+			appliedAt := row.appliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Modified = row.checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Create writes an empty up/down pair for the next version into the
+// subdirectory of dir for every dialect and returns the created paths.
+func Create(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), "_"))
+	if !migrateName.MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name %q", name)
+	}
+
+	var next int64 = 1
+	for _, dialect := range Dialects {
+		migrations, err := load(os.DirFS(filepath.Join(dir, dialect)))
+		if err != nil {
+			return nil, err
+		}
+		if len(migrations) > 0 && migrations[len(migrations)-1].Version >= next {
+			next = migrations[len(migrations)-1].Version + 1
+		}
+	}
+
+	var created []string
+	for _, dialect := range Dialects {
+		base := filepath.Join(dir, dialect, fmt.Sprintf("%04d_%s", next, name))
+		if err := os.WriteFile(base+".up.sql", []byte("-- "+name+"\n"), 0o644); err != nil {
+			return created, err
+		}
+		if err := os.WriteFile(base+".down.sql", []byte("-- revert "+name+"\n"), 0o644); err != nil {
+			return created, err
+		}
+		created = append(created, base+".up.sql", base+".down.sql")
+	}
+	return created, nil
+}
+
+func (m *Migrator) verify(done map[int64]applied) error {
+	for _, migration := range m.migrations {
+		if row, ok := done[migration.Version]; ok && row.checksum != migration.Checksum {
+			return fmt.Errorf("migration %d_%s was modified after it was applied", migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+// applied creates the table of applied migrations when missing and returns
+// them by version.
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]applied, error) {
+	timestamp := "DATETIME"
+	if m.dialect == "postgres" {
+		timestamp = "TIMESTAMP WITH TIME ZONE"
+	}
+	_, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+tableName+` (
+		version BIGINT NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum VARCHAR(64) NOT NULL,
+		applied_at `+timestamp+` NOT NULL
+	)`)
+	if err != nil {
+		return nil, err
+	}
+	return m.readApplied(ctx, conn)
+}
+
+// tableExists reports whether the table of applied migrations exists.
+func (m *Migrator) tableExists(ctx context.Context, conn *sql.Conn) (bool, error) {
+	query := "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+	switch m.dialect {
+	case "postgres":
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?"
+	case "mysql":
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+	}
+	var count int
+	if err := conn.QueryRowContext(ctx, m.bind(query), tableName).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// readApplied returns the applied migrations by version.
+func (m *Migrator) readApplied(ctx context.Context, conn *sql.Conn) (map[int64]applied, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, checksum, applied_at FROM "+tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[int64]applied{}
+	for rows.Next() {
+		var version int64
+		var row applied
+		if err := rows.Scan(&version, &row.checksum, &row.appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = row
+	}
+	return done, rows.Err()
+}
+
+// exec runs the statements of a migration file followed by the bookkeeping
+// statement in one transaction. MySQL commits DDL implicitly, so a failing
+// migration there may be partially applied.
+func (m *Migrator) exec(ctx context.Context, conn *sql.Conn, script, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, statement := range splitStatements(script) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// withLock holds a database advisory lock on a dedicated connection so only
+// one instance migrates at a time. SQLite databases are local to a single
+// process, so they need no lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	switch m.dialect {
+	case "mysql":
+		var locked sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockWait).Scan(&locked); err != nil {
+			return err
+		}
+		if locked.Int64 != 1 {
+			return fmt.Errorf("timed out waiting for migration lock")
+		}
+		defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName)
+	case "postgres":
+		lockCtx, cancel := context.WithTimeout(ctx, lockWait*time.Second)
+		defer cancel()
+		if _, err := conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			return fmt.Errorf("waiting for migration lock: %w", err)
+		}
+		defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+	}
+
+	return fn(conn)
+}
+
+// bind rewrites ? placeholders into the $n form PostgreSQL expects.
+func (m *Migrator) bind(query string) string {
+	if m.dialect != "postgres" {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// splitStatements splits a script on semicolons that are not inside quotes
+// or comments.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	var quote rune
+	lineComment := false
+
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case lineComment:
+			if r == '\n' {
+				lineComment = false
+			}
+			continue
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			lineComment = true
+			continue
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == ';':
+			if statement := strings.TrimSpace(current.String()); statement != "" {
+				statements = append(statements, statement)
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if statement := strings.TrimSpace(current.String()); statement != "" {
+		statements = append(statements, statement)
+	}
+	return statements
+}
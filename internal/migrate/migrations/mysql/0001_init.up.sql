@@ -0,0 +1,74 @@
+-- Baseline schema matching what GORM AutoMigrate produced before versioned
+-- migrations were introduced. IF NOT EXISTS lets existing databases adopt it.
+CREATE TABLE IF NOT EXISTS `exercise_categories` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `deleted_at` DATETIME NULL,
+  `name` varchar(255) NOT NULL UNIQUE,
+  PRIMARY KEY (`id`),
+  INDEX `idx_exercise_categories_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `exercises` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `deleted_at` DATETIME NULL,
+  `name` varchar(255) NOT NULL UNIQUE,
+  `description` varchar(255),
+  `category` int,
+  `muscle_group` varchar(255),
+  PRIMARY KEY (`id`),
+  INDEX `idx_exercises_deleted_at` (`deleted_at`),
+  INDEX `idx_exercises_muscle_group` (`muscle_group`)
+);
+
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `deleted_at` DATETIME NULL,
+  `first_name` varchar(255),
+  `last_name` varchar(255),
+  `email` varchar(255) NOT NULL UNIQUE,
+  `password` varchar(255),
+  `is_verified` boolean DEFAULT false,
+  `verify_token` varchar(255) DEFAULT null,
+  `verify_exp_time` bigint DEFAULT null,
+  `reset_token` varchar(255) DEFAULT null,
+  `reset_exp_time` bigint DEFAULT null,
+  PRIMARY KEY (`id`),
+  INDEX `idx_users_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `workout_plans` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `deleted_at` DATETIME NULL,
+  `name` varchar(255) NOT NULL,
+  `description` varchar(255),
+  `user_id` bigint,
+  `exercise_id` bigint,
+  `sets` bigint NOT NULL,
+  `repetitions` bigint NOT NULL,
+  `weight` double NOT NULL,
+  `order` bigint NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_workout_plans_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `workout_schedules` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `deleted_at` DATETIME NULL,
+  `user_id` bigint,
+  `workout_plan_id` bigint,
+  `scheduled_date` DATETIME NOT NULL,
+  `status` varchar(255) DEFAULT 'scheduled',
+  `completed_date` DATETIME NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_workout_schedules_deleted_at` (`deleted_at`)
+);
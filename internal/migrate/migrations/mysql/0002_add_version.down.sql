@@ -0,0 +1,2 @@
+ALTER TABLE `workout_schedules` DROP COLUMN `version`;
+ALTER TABLE `workout_plans` DROP COLUMN `version`;
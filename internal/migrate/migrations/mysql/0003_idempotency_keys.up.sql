@@ -0,0 +1,14 @@
+CREATE TABLE `idempotency_keys` (
+  `id` int unsigned AUTO_INCREMENT,
+  `user_id` bigint NOT NULL,
+  `idempotency_key` varchar(255) NOT NULL,
+  `fingerprint` varchar(64) NOT NULL,
+  `status_code` int NOT NULL DEFAULT 0,
+  `content_type` varchar(255),
+  `body` mediumtext,
+  `created_at` DATETIME NULL,
+  `expires_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_idempotency_keys_user_key` (`user_id`, `idempotency_key`),
+  INDEX `idx_idempotency_keys_expires_at` (`expires_at`)
+);
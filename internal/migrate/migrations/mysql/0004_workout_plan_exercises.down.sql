@@ -0,0 +1,14 @@
+-- Plans keep only their first exercise.
+ALTER TABLE `workout_plans`
+  ADD COLUMN `exercise_id` bigint,
+  ADD COLUMN `sets` bigint NOT NULL DEFAULT 0,
+  ADD COLUMN `repetitions` bigint NOT NULL DEFAULT 0,
+  ADD COLUMN `weight` double NOT NULL DEFAULT 0;
+
+UPDATE `workout_plans` p
+JOIN `workout_plan_exercises` e ON e.`workout_plan_id` = p.`id` AND e.`position` = (
+  SELECT MIN(f.`position`) FROM `workout_plan_exercises` f WHERE f.`workout_plan_id` = p.`id`
+)
+SET p.`exercise_id` = e.`exercise_id`, p.`sets` = e.`sets`, p.`repetitions` = e.`repetitions`, p.`weight` = e.`weight`;
+
+DROP TABLE `workout_plan_exercises`;
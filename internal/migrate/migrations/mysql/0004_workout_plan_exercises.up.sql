@@ -0,0 +1,22 @@
+-- A plan holds an ordered list of exercises instead of a single one. Every
+-- existing plan becomes a plan with one entry.
+CREATE TABLE `workout_plan_exercises` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `workout_plan_id` bigint NOT NULL,
+  `exercise_id` bigint NOT NULL,
+  `position` int NOT NULL,
+  `sets` bigint NOT NULL,
+  `repetitions` bigint NOT NULL,
+  `weight` double NOT NULL,
+  `rest_seconds` int NOT NULL DEFAULT 0,
+  `notes` varchar(1000),
+  PRIMARY KEY (`id`),
+  INDEX `idx_workout_plan_exercises_plan_position` (`workout_plan_id`, `position`)
+);
+
+INSERT INTO `workout_plan_exercises` (`created_at`, `updated_at`, `workout_plan_id`, `exercise_id`, `position`, `sets`, `repetitions`, `weight`)
+SELECT `created_at`, `updated_at`, `id`, `exercise_id`, 1, `sets`, `repetitions`, `weight` FROM `workout_plans` WHERE `exercise_id` IS NOT NULL;
+
+ALTER TABLE `workout_plans` DROP COLUMN `exercise_id`, DROP COLUMN `sets`, DROP COLUMN `repetitions`, DROP COLUMN `weight`;
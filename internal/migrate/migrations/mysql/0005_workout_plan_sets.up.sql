@@ -0,0 +1,17 @@
+CREATE TABLE `workout_plan_sets` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `workout_plan_exercise_id` bigint NOT NULL,
+  `position` int NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `reps` int,
+  `max_reps` int,
+  `weight` double,
+  `percent_1rm` double,
+  `rpe` double,
+  `rir` int,
+  `rest_seconds` int NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  INDEX `idx_workout_plan_sets_exercise_position` (`workout_plan_exercise_id`, `position`)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE `workout_plan_groups` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `workout_plan_id` bigint NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `rounds` int,
+  `rest_seconds` int NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  INDEX `idx_workout_plan_groups_workout_plan_id` (`workout_plan_id`)
+);
+
+ALTER TABLE `workout_plan_exercises` ADD COLUMN `group_id` bigint;
@@ -0,0 +1,35 @@
+CREATE TABLE `workout_sessions` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `deleted_at` DATETIME NULL,
+  `user_id` bigint NOT NULL,
+  `workout_plan_id` bigint,
+  `workout_schedule_id` bigint,
+  `status` varchar(16) NOT NULL,
+  `started_at` DATETIME NOT NULL,
+  `finished_at` DATETIME NULL,
+  `notes` text,
+  PRIMARY KEY (`id`),
+  INDEX `idx_workout_sessions_deleted_at` (`deleted_at`),
+  INDEX `idx_workout_sessions_user_status` (`user_id`, `status`)
+);
+
+CREATE TABLE `workout_session_sets` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `workout_session_id` bigint NOT NULL,
+  `workout_plan_exercise_id` bigint,
+  `exercise_id` bigint NOT NULL,
+  `position` int NOT NULL,
+  `reps` int NOT NULL DEFAULT 0,
+  `weight` double NOT NULL DEFAULT 0,
+  `rpe` double,
+  `duration_seconds` int NOT NULL DEFAULT 0,
+  `notes` text,
+  `performed_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_workout_session_sets_session_position` (`workout_session_id`, `position`),
+  INDEX `idx_workout_session_sets_exercise_id` (`exercise_id`)
+);
@@ -0,0 +1,18 @@
+ALTER TABLE `workout_session_sets` DROP COLUMN `calories`;
+ALTER TABLE `workout_session_sets` DROP COLUMN `heart_rate`;
+ALTER TABLE `workout_session_sets` DROP COLUMN `elevation_meters`;
+ALTER TABLE `workout_session_sets` DROP COLUMN `pace_seconds_per_km`;
+ALTER TABLE `workout_session_sets` DROP COLUMN `distance_meters`;
+ALTER TABLE `workout_plan_sets` DROP COLUMN `calories`;
+ALTER TABLE `workout_plan_sets` DROP COLUMN `heart_rate`;
+ALTER TABLE `workout_plan_sets` DROP COLUMN `elevation_meters`;
+ALTER TABLE `workout_plan_sets` DROP COLUMN `pace_seconds_per_km`;
+ALTER TABLE `workout_plan_sets` DROP COLUMN `distance_meters`;
+ALTER TABLE `workout_plan_sets` DROP COLUMN `duration_seconds`;
+ALTER TABLE `workout_plan_exercises` DROP COLUMN `calories`;
+ALTER TABLE `workout_plan_exercises` DROP COLUMN `heart_rate`;
+ALTER TABLE `workout_plan_exercises` DROP COLUMN `elevation_meters`;
+ALTER TABLE `workout_plan_exercises` DROP COLUMN `pace_seconds_per_km`;
+ALTER TABLE `workout_plan_exercises` DROP COLUMN `distance_meters`;
+ALTER TABLE `workout_plan_exercises` DROP COLUMN `duration_seconds`;
+ALTER TABLE `exercises` DROP COLUMN `measurement`;
@@ -0,0 +1,26 @@
+-- Exercises are measured by reps and load unless they say otherwise. The
+-- seeded bodyweight, isometric and cardio exercises get their own kind.
+ALTER TABLE `exercises` ADD COLUMN `measurement` varchar(32) NOT NULL DEFAULT 'reps_load';
+UPDATE `exercises` SET `measurement` = 'reps' WHERE `name` IN ('Push-up', 'Pull-up');
+UPDATE `exercises` SET `measurement` = 'duration' WHERE `name` IN ('Plank', 'Hamstring Stretch');
+UPDATE `exercises` SET `measurement` = 'distance_duration' WHERE `name` = 'Running';
+
+ALTER TABLE `workout_plan_exercises` ADD COLUMN `duration_seconds` int NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_exercises` ADD COLUMN `distance_meters` double NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_exercises` ADD COLUMN `pace_seconds_per_km` int NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_exercises` ADD COLUMN `elevation_meters` double NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_exercises` ADD COLUMN `heart_rate` int NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_exercises` ADD COLUMN `calories` int NOT NULL DEFAULT 0;
+
+ALTER TABLE `workout_plan_sets` ADD COLUMN `duration_seconds` int NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_sets` ADD COLUMN `distance_meters` double NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_sets` ADD COLUMN `pace_seconds_per_km` int NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_sets` ADD COLUMN `elevation_meters` double NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_sets` ADD COLUMN `heart_rate` int NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_sets` ADD COLUMN `calories` int NOT NULL DEFAULT 0;
+
+ALTER TABLE `workout_session_sets` ADD COLUMN `distance_meters` double NOT NULL DEFAULT 0;
+ALTER TABLE `workout_session_sets` ADD COLUMN `pace_seconds_per_km` int NOT NULL DEFAULT 0;
+ALTER TABLE `workout_session_sets` ADD COLUMN `elevation_meters` double NOT NULL DEFAULT 0;
+ALTER TABLE `workout_session_sets` ADD COLUMN `heart_rate` int NOT NULL DEFAULT 0;
+ALTER TABLE `workout_session_sets` ADD COLUMN `calories` int NOT NULL DEFAULT 0;
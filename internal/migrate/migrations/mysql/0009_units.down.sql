@@ -0,0 +1,14 @@
+ALTER TABLE `workout_session_sets`
+  MODIFY `weight` double NOT NULL DEFAULT 0,
+  MODIFY `distance_meters` double NOT NULL DEFAULT 0,
+  MODIFY `elevation_meters` double NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_sets`
+  MODIFY `weight` double,
+  MODIFY `distance_meters` double NOT NULL DEFAULT 0,
+  MODIFY `elevation_meters` double NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_exercises`
+  MODIFY `weight` double NOT NULL,
+  MODIFY `distance_meters` double NOT NULL DEFAULT 0,
+  MODIFY `elevation_meters` double NOT NULL DEFAULT 0;
+
+ALTER TABLE `users` DROP COLUMN `preferred_units`;
@@ -0,0 +1,16 @@
+-- Loads and distances are stored in SI units as exact decimals: weights in
+-- kilograms, distances and elevations in meters.
+ALTER TABLE `users` ADD COLUMN `preferred_units` varchar(16) NOT NULL DEFAULT 'metric';
+
+ALTER TABLE `workout_plan_exercises`
+  MODIFY `weight` decimal(10,3) NOT NULL,
+  MODIFY `distance_meters` decimal(12,3) NOT NULL DEFAULT 0,
+  MODIFY `elevation_meters` decimal(10,3) NOT NULL DEFAULT 0;
+ALTER TABLE `workout_plan_sets`
+  MODIFY `weight` decimal(10,3),
+  MODIFY `distance_meters` decimal(12,3) NOT NULL DEFAULT 0,
+  MODIFY `elevation_meters` decimal(10,3) NOT NULL DEFAULT 0;
+ALTER TABLE `workout_session_sets`
+  MODIFY `weight` decimal(10,3) NOT NULL DEFAULT 0,
+  MODIFY `distance_meters` decimal(12,3) NOT NULL DEFAULT 0,
+  MODIFY `elevation_meters` decimal(10,3) NOT NULL DEFAULT 0;
@@ -0,0 +1,19 @@
+CREATE TABLE `personal_records` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `user_id` bigint NOT NULL,
+  `exercise_id` bigint NOT NULL,
+  `type` varchar(32) NOT NULL,
+  `weight` decimal(10,3) NOT NULL DEFAULT 0,
+  `reps` int NOT NULL DEFAULT 0,
+  `estimated_1rm` decimal(10,3) NOT NULL DEFAULT 0,
+  `volume` decimal(14,3) NOT NULL DEFAULT 0,
+  `distance_meters` decimal(12,3) NOT NULL DEFAULT 0,
+  `duration_seconds` int NOT NULL DEFAULT 0,
+  `workout_session_id` bigint NOT NULL,
+  `workout_session_set_id` bigint,
+  `achieved_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_personal_records_user_exercise_type` (`user_id`, `exercise_id`, `type`),
+  INDEX `idx_personal_records_workout_session_id` (`workout_session_id`)
+);
@@ -0,0 +1,12 @@
+ALTER TABLE `users` ADD COLUMN `one_rm_formula` varchar(16) NOT NULL DEFAULT 'epley';
+
+CREATE TABLE `training_maxes` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `user_id` bigint NOT NULL,
+  `exercise_id` bigint NOT NULL,
+  `weight` decimal(10,3) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_training_maxes_user_exercise` (`user_id`, `exercise_id`)
+);
@@ -0,0 +1,29 @@
+CREATE TABLE `workout_plan_progressions` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `workout_plan_exercise_id` bigint NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `increment` decimal(10,3) NOT NULL DEFAULT 0,
+  `deload_after` int NOT NULL DEFAULT 0,
+  `deload_percent` double NOT NULL DEFAULT 0,
+  `target_rpe` double NOT NULL DEFAULT 0,
+  `failures` int NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_workout_plan_progressions_exercise` (`workout_plan_exercise_id`)
+);
+
+CREATE TABLE `progression_adjustments` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `workout_session_id` bigint NOT NULL,
+  `workout_plan_exercise_id` bigint NOT NULL,
+  `workout_schedule_id` bigint,
+  `exercise_id` bigint NOT NULL,
+  `outcome` varchar(16) NOT NULL,
+  `previous_weight` decimal(10,3) NOT NULL DEFAULT 0,
+  `weight` decimal(10,3) NOT NULL DEFAULT 0,
+  `explanation` varchar(1000) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_progression_adjustments_workout_session_id` (`workout_session_id`)
+);
@@ -0,0 +1,13 @@
+ALTER TABLE `workout_schedules`
+  DROP INDEX `idx_workout_schedules_program_enrollment_id`,
+  DROP COLUMN `volume_percent`,
+  DROP COLUMN `intensity_percent`,
+  DROP COLUMN `program_week`,
+  DROP COLUMN `program_enrollment_id`;
+
+DROP TABLE IF EXISTS `program_enrollments`;
+DROP TABLE IF EXISTS `program_days`;
+DROP TABLE IF EXISTS `program_weeks`;
+DROP TABLE IF EXISTS `programs`;
+
+ALTER TABLE `users` DROP COLUMN `training_days`;
@@ -0,0 +1,57 @@
+ALTER TABLE `users` ADD COLUMN `training_days` varchar(32) NOT NULL DEFAULT '';
+
+CREATE TABLE `programs` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `deleted_at` DATETIME NULL,
+  `user_id` bigint NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `description` varchar(1000),
+  `version` bigint NOT NULL DEFAULT 1,
+  PRIMARY KEY (`id`),
+  INDEX `idx_programs_deleted_at` (`deleted_at`),
+  INDEX `idx_programs_user_id` (`user_id`)
+);
+
+CREATE TABLE `program_weeks` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `program_id` bigint NOT NULL,
+  `number` int NOT NULL,
+  `intensity_percent` double NOT NULL DEFAULT 100,
+  `volume_percent` double NOT NULL DEFAULT 100,
+  `notes` varchar(1000),
+  PRIMARY KEY (`id`),
+  INDEX `idx_program_weeks_program_id` (`program_id`)
+);
+
+CREATE TABLE `program_days` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `updated_at` DATETIME NULL,
+  `program_id` bigint NOT NULL,
+  `position` int NOT NULL,
+  `workout_plan_id` bigint NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_program_days_program_id` (`program_id`)
+);
+
+CREATE TABLE `program_enrollments` (
+  `id` int unsigned AUTO_INCREMENT,
+  `created_at` DATETIME NULL,
+  `user_id` bigint NOT NULL,
+  `program_id` bigint NOT NULL,
+  `start_date` DATETIME NOT NULL,
+  `training_days` varchar(32) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_program_enrollments_user_id` (`user_id`)
+);
+
+ALTER TABLE `workout_schedules`
+  ADD COLUMN `program_enrollment_id` bigint,
+  ADD COLUMN `program_week` int NOT NULL DEFAULT 0,
+  ADD COLUMN `intensity_percent` double NOT NULL DEFAULT 0,
+  ADD COLUMN `volume_percent` double NOT NULL DEFAULT 0,
+  ADD INDEX `idx_workout_schedules_program_enrollment_id` (`program_enrollment_id`);
@@ -0,0 +1,3 @@
+ALTER TABLE `workout_sessions`
+  DROP INDEX `idx_workout_sessions_in_progress`,
+  DROP COLUMN `in_progress_user_id`;
@@ -0,0 +1,13 @@
+-- A user has at most one session in progress. Sessions left in progress by
+-- concurrent starts before this index existed are finished, keeping the
+-- latest one open.
+UPDATE `workout_sessions` SET `status` = 'finished', `finished_at` = `started_at`
+WHERE `status` = 'in_progress'
+  AND `id` NOT IN (SELECT `id` FROM (SELECT MAX(`id`) AS `id` FROM `workout_sessions` WHERE `status` = 'in_progress' GROUP BY `user_id`) AS `latest`);
+
+-- MySQL has no partial indexes, so the index covers a column that holds the
+-- user only while the session is in progress; unique indexes allow any
+-- number of NULLs.
+ALTER TABLE `workout_sessions`
+  ADD COLUMN `in_progress_user_id` bigint AS (CASE WHEN `status` = 'in_progress' THEN `user_id` END) STORED,
+  ADD UNIQUE INDEX `idx_workout_sessions_in_progress` (`in_progress_user_id`);
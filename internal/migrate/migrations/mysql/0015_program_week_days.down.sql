@@ -0,0 +1,5 @@
+-- Days of a single week would otherwise be done every week.
+DELETE FROM `program_days` WHERE `program_week_id` IS NOT NULL;
+ALTER TABLE `program_days`
+  DROP INDEX `idx_program_days_program_week_id`,
+  DROP COLUMN `program_week_id`;
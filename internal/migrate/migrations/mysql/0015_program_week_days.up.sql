@@ -0,0 +1,3 @@
+ALTER TABLE `program_days`
+  ADD COLUMN `program_week_id` bigint,
+  ADD INDEX `idx_program_days_program_week_id` (`program_week_id`);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS workout_schedules;
+DROP TABLE IF EXISTS workout_plans;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS exercises;
+DROP TABLE IF EXISTS exercise_categories;
@@ -0,0 +1,13 @@
+-- Plans keep only their first exercise.
+ALTER TABLE workout_plans ADD COLUMN exercise_id BIGINT;
+ALTER TABLE workout_plans ADD COLUMN sets BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE workout_plans ADD COLUMN repetitions BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE workout_plans ADD COLUMN weight DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+UPDATE workout_plans SET
+  exercise_id = (SELECT e.exercise_id FROM workout_plan_exercises e WHERE e.workout_plan_id = workout_plans.id ORDER BY e.position LIMIT 1),
+  sets = COALESCE((SELECT e.sets FROM workout_plan_exercises e WHERE e.workout_plan_id = workout_plans.id ORDER BY e.position LIMIT 1), 0),
+  repetitions = COALESCE((SELECT e.repetitions FROM workout_plan_exercises e WHERE e.workout_plan_id = workout_plans.id ORDER BY e.position LIMIT 1), 0),
+  weight = COALESCE((SELECT e.weight FROM workout_plan_exercises e WHERE e.workout_plan_id = workout_plans.id ORDER BY e.position LIMIT 1), 0);
+
+DROP TABLE workout_plan_exercises;
@@ -0,0 +1,16 @@
+CREATE TABLE workout_plan_sets (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  workout_plan_exercise_id BIGINT NOT NULL,
+  position INTEGER NOT NULL,
+  type VARCHAR(16) NOT NULL,
+  reps INTEGER,
+  max_reps INTEGER,
+  weight DOUBLE PRECISION,
+  percent_1rm DOUBLE PRECISION,
+  rpe DOUBLE PRECISION,
+  rir INTEGER,
+  rest_seconds INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_workout_plan_sets_exercise_position ON workout_plan_sets (workout_plan_exercise_id, position);
@@ -0,0 +1,2 @@
+ALTER TABLE workout_plan_exercises DROP COLUMN group_id;
+DROP TABLE IF EXISTS workout_plan_groups;
@@ -0,0 +1,12 @@
+CREATE TABLE workout_plan_groups (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  workout_plan_id BIGINT NOT NULL,
+  type VARCHAR(16) NOT NULL,
+  rounds INTEGER,
+  rest_seconds INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_workout_plan_groups_workout_plan_id ON workout_plan_groups (workout_plan_id);
+
+ALTER TABLE workout_plan_exercises ADD COLUMN group_id BIGINT;
@@ -0,0 +1,33 @@
+CREATE TABLE workout_sessions (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE NULL,
+  user_id BIGINT NOT NULL,
+  workout_plan_id BIGINT,
+  workout_schedule_id BIGINT,
+  status VARCHAR(16) NOT NULL,
+  started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  finished_at TIMESTAMP WITH TIME ZONE NULL,
+  notes TEXT
+);
+CREATE INDEX idx_workout_sessions_deleted_at ON workout_sessions (deleted_at);
+CREATE INDEX idx_workout_sessions_user_status ON workout_sessions (user_id, status);
+
+CREATE TABLE workout_session_sets (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  workout_session_id BIGINT NOT NULL,
+  workout_plan_exercise_id BIGINT,
+  exercise_id BIGINT NOT NULL,
+  position INTEGER NOT NULL,
+  reps INTEGER NOT NULL DEFAULT 0,
+  weight DOUBLE PRECISION NOT NULL DEFAULT 0,
+  rpe DOUBLE PRECISION,
+  duration_seconds INTEGER NOT NULL DEFAULT 0,
+  notes TEXT,
+  performed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX idx_workout_session_sets_session_position ON workout_session_sets (workout_session_id, position);
+CREATE INDEX idx_workout_session_sets_exercise_id ON workout_session_sets (exercise_id);
@@ -0,0 +1,14 @@
+ALTER TABLE workout_session_sets
+  ALTER COLUMN weight TYPE DOUBLE PRECISION,
+  ALTER COLUMN distance_meters TYPE DOUBLE PRECISION,
+  ALTER COLUMN elevation_meters TYPE DOUBLE PRECISION;
+ALTER TABLE workout_plan_sets
+  ALTER COLUMN weight TYPE DOUBLE PRECISION,
+  ALTER COLUMN distance_meters TYPE DOUBLE PRECISION,
+  ALTER COLUMN elevation_meters TYPE DOUBLE PRECISION;
+ALTER TABLE workout_plan_exercises
+  ALTER COLUMN weight TYPE DOUBLE PRECISION,
+  ALTER COLUMN distance_meters TYPE DOUBLE PRECISION,
+  ALTER COLUMN elevation_meters TYPE DOUBLE PRECISION;
+
+ALTER TABLE users DROP COLUMN preferred_units;
@@ -0,0 +1,16 @@
+-- Loads and distances are stored in SI units as exact decimals: weights in
+-- kilograms, distances and elevations in meters.
+ALTER TABLE users ADD COLUMN preferred_units VARCHAR(16) NOT NULL DEFAULT 'metric';
+
+ALTER TABLE workout_plan_exercises
+  ALTER COLUMN weight TYPE NUMERIC(10,3),
+  ALTER COLUMN distance_meters TYPE NUMERIC(12,3),
+  ALTER COLUMN elevation_meters TYPE NUMERIC(10,3);
+ALTER TABLE workout_plan_sets
+  ALTER COLUMN weight TYPE NUMERIC(10,3),
+  ALTER COLUMN distance_meters TYPE NUMERIC(12,3),
+  ALTER COLUMN elevation_meters TYPE NUMERIC(10,3);
+ALTER TABLE workout_session_sets
+  ALTER COLUMN weight TYPE NUMERIC(10,3),
+  ALTER COLUMN distance_meters TYPE NUMERIC(12,3),
+  ALTER COLUMN elevation_meters TYPE NUMERIC(10,3);
@@ -0,0 +1,18 @@
+CREATE TABLE personal_records (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  user_id BIGINT NOT NULL,
+  exercise_id BIGINT NOT NULL,
+  type VARCHAR(32) NOT NULL,
+  weight NUMERIC(10,3) NOT NULL DEFAULT 0,
+  reps INTEGER NOT NULL DEFAULT 0,
+  estimated_1rm NUMERIC(10,3) NOT NULL DEFAULT 0,
+  volume NUMERIC(14,3) NOT NULL DEFAULT 0,
+  distance_meters NUMERIC(12,3) NOT NULL DEFAULT 0,
+  duration_seconds INTEGER NOT NULL DEFAULT 0,
+  workout_session_id BIGINT NOT NULL,
+  workout_session_set_id BIGINT,
+  achieved_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX idx_personal_records_user_exercise_type ON personal_records (user_id, exercise_id, type);
+CREATE INDEX idx_personal_records_workout_session_id ON personal_records (workout_session_id);
@@ -0,0 +1,11 @@
+ALTER TABLE users ADD COLUMN one_rm_formula VARCHAR(16) NOT NULL DEFAULT 'epley';
+
+CREATE TABLE training_maxes (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  user_id BIGINT NOT NULL,
+  exercise_id BIGINT NOT NULL,
+  weight NUMERIC(10,3) NOT NULL
+);
+CREATE UNIQUE INDEX idx_training_maxes_user_exercise ON training_maxes (user_id, exercise_id);
@@ -0,0 +1,27 @@
+CREATE TABLE workout_plan_progressions (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  workout_plan_exercise_id BIGINT NOT NULL,
+  type VARCHAR(16) NOT NULL,
+  increment NUMERIC(10,3) NOT NULL DEFAULT 0,
+  deload_after INTEGER NOT NULL DEFAULT 0,
+  deload_percent DOUBLE PRECISION NOT NULL DEFAULT 0,
+  target_rpe DOUBLE PRECISION NOT NULL DEFAULT 0,
+  failures INTEGER NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_workout_plan_progressions_exercise ON workout_plan_progressions (workout_plan_exercise_id);
+
+CREATE TABLE progression_adjustments (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  workout_session_id BIGINT NOT NULL,
+  workout_plan_exercise_id BIGINT NOT NULL,
+  workout_schedule_id BIGINT,
+  exercise_id BIGINT NOT NULL,
+  outcome VARCHAR(16) NOT NULL,
+  previous_weight NUMERIC(10,3) NOT NULL DEFAULT 0,
+  weight NUMERIC(10,3) NOT NULL DEFAULT 0,
+  explanation VARCHAR(1000) NOT NULL
+);
+CREATE INDEX idx_progression_adjustments_workout_session_id ON progression_adjustments (workout_session_id);
@@ -0,0 +1,52 @@
+ALTER TABLE users ADD COLUMN training_days VARCHAR(32) NOT NULL DEFAULT '';
+
+CREATE TABLE programs (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  deleted_at TIMESTAMP WITH TIME ZONE NULL,
+  user_id BIGINT NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  description VARCHAR(1000),
+  version BIGINT NOT NULL DEFAULT 1
+);
+CREATE INDEX idx_programs_deleted_at ON programs (deleted_at);
+CREATE INDEX idx_programs_user_id ON programs (user_id);
+
+CREATE TABLE program_weeks (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  program_id BIGINT NOT NULL,
+  number INTEGER NOT NULL,
+  intensity_percent DOUBLE PRECISION NOT NULL DEFAULT 100,
+  volume_percent DOUBLE PRECISION NOT NULL DEFAULT 100,
+  notes VARCHAR(1000)
+);
+CREATE INDEX idx_program_weeks_program_id ON program_weeks (program_id);
+
+CREATE TABLE program_days (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  program_id BIGINT NOT NULL,
+  position INTEGER NOT NULL,
+  workout_plan_id BIGINT NOT NULL
+);
+CREATE INDEX idx_program_days_program_id ON program_days (program_id);
+
+CREATE TABLE program_enrollments (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NULL,
+  user_id BIGINT NOT NULL,
+  program_id BIGINT NOT NULL,
+  start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+  training_days VARCHAR(32) NOT NULL
+);
+CREATE INDEX idx_program_enrollments_user_id ON program_enrollments (user_id);
+
+ALTER TABLE workout_schedules ADD COLUMN program_enrollment_id BIGINT;
+ALTER TABLE workout_schedules ADD COLUMN program_week INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE workout_schedules ADD COLUMN intensity_percent DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE workout_schedules ADD COLUMN volume_percent DOUBLE PRECISION NOT NULL DEFAULT 0;
+CREATE INDEX idx_workout_schedules_program_enrollment_id ON workout_schedules (program_enrollment_id);
@@ -0,0 +1,8 @@
+-- A user has at most one session in progress. Sessions left in progress by
+-- concurrent starts before this index existed are finished, keeping the
+-- latest one open.
+UPDATE workout_sessions SET status = 'finished', finished_at = started_at
+WHERE status = 'in_progress'
+  AND id NOT IN (SELECT MAX(id) FROM workout_sessions WHERE status = 'in_progress' GROUP BY user_id);
+
+CREATE UNIQUE INDEX idx_workout_sessions_in_progress ON workout_sessions (user_id) WHERE status = 'in_progress';
@@ -0,0 +1,4 @@
+-- Days of a single week would otherwise be done every week.
+DELETE FROM program_days WHERE program_week_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_program_days_program_week_id;
+ALTER TABLE program_days DROP COLUMN program_week_id;
@@ -0,0 +1,69 @@
+-- Baseline schema matching what GORM AutoMigrate produced before versioned
+-- migrations were introduced. IF NOT EXISTS lets existing databases adopt it.
+CREATE TABLE IF NOT EXISTS exercise_categories (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  deleted_at DATETIME NULL,
+  name VARCHAR(255) NOT NULL UNIQUE
+);
+CREATE INDEX IF NOT EXISTS idx_exercise_categories_deleted_at ON exercise_categories (deleted_at);
+
+CREATE TABLE IF NOT EXISTS exercises (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  deleted_at DATETIME NULL,
+  name VARCHAR(255) NOT NULL UNIQUE,
+  description VARCHAR(255),
+  category INTEGER,
+  muscle_group VARCHAR(255)
+);
+CREATE INDEX IF NOT EXISTS idx_exercises_deleted_at ON exercises (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_exercises_muscle_group ON exercises (muscle_group);
+
+CREATE TABLE IF NOT EXISTS users (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  deleted_at DATETIME NULL,
+  first_name VARCHAR(255),
+  last_name VARCHAR(255),
+  email VARCHAR(255) NOT NULL UNIQUE,
+  password VARCHAR(255),
+  is_verified BOOLEAN DEFAULT false,
+  verify_token VARCHAR(255) DEFAULT null,
+  verify_exp_time BIGINT DEFAULT null,
+  reset_token VARCHAR(255) DEFAULT null,
+  reset_exp_time BIGINT DEFAULT null
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS workout_plans (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  deleted_at DATETIME NULL,
+  name VARCHAR(255) NOT NULL,
+  description VARCHAR(255),
+  user_id BIGINT,
+  exercise_id BIGINT,
+  sets BIGINT NOT NULL,
+  repetitions BIGINT NOT NULL,
+  weight REAL NOT NULL,
+  "order" BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_workout_plans_deleted_at ON workout_plans (deleted_at);
+
+CREATE TABLE IF NOT EXISTS workout_schedules (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  deleted_at DATETIME NULL,
+  user_id BIGINT,
+  workout_plan_id BIGINT,
+  scheduled_date DATETIME NOT NULL,
+  status VARCHAR(255) DEFAULT 'scheduled',
+  completed_date DATETIME NULL
+);
+CREATE INDEX IF NOT EXISTS idx_workout_schedules_deleted_at ON workout_schedules (deleted_at);
@@ -0,0 +1,2 @@
+ALTER TABLE workout_plans ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE workout_schedules ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
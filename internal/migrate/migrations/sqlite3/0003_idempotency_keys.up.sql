@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_keys (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id BIGINT NOT NULL,
+  idempotency_key VARCHAR(255) NOT NULL,
+  fingerprint VARCHAR(64) NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  content_type VARCHAR(255),
+  body TEXT,
+  created_at DATETIME NULL,
+  expires_at DATETIME NOT NULL
+);
+CREATE UNIQUE INDEX idx_idempotency_keys_user_key ON idempotency_keys (user_id, idempotency_key);
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
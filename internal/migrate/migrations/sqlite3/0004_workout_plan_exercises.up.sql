@@ -0,0 +1,24 @@
+-- A plan holds an ordered list of exercises instead of a single one. Every
+-- existing plan becomes a plan with one entry.
+CREATE TABLE workout_plan_exercises (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  workout_plan_id BIGINT NOT NULL,
+  exercise_id BIGINT NOT NULL,
+  position INTEGER NOT NULL,
+  sets BIGINT NOT NULL,
+  repetitions BIGINT NOT NULL,
+  weight REAL NOT NULL,
+  rest_seconds INTEGER NOT NULL DEFAULT 0,
+  notes VARCHAR(1000)
+);
+CREATE INDEX idx_workout_plan_exercises_plan_position ON workout_plan_exercises (workout_plan_id, position);
+
+INSERT INTO workout_plan_exercises (created_at, updated_at, workout_plan_id, exercise_id, position, sets, repetitions, weight)
+SELECT created_at, updated_at, id, exercise_id, 1, sets, repetitions, weight FROM workout_plans WHERE exercise_id IS NOT NULL;
+
+ALTER TABLE workout_plans DROP COLUMN exercise_id;
+ALTER TABLE workout_plans DROP COLUMN sets;
+ALTER TABLE workout_plans DROP COLUMN repetitions;
+ALTER TABLE workout_plans DROP COLUMN weight;
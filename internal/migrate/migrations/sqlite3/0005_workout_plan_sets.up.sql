@@ -0,0 +1,16 @@
+CREATE TABLE workout_plan_sets (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  workout_plan_exercise_id BIGINT NOT NULL,
+  position INTEGER NOT NULL,
+  type VARCHAR(16) NOT NULL,
+  reps INTEGER,
+  max_reps INTEGER,
+  weight REAL,
+  percent_1rm REAL,
+  rpe REAL,
+  rir INTEGER,
+  rest_seconds INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_workout_plan_sets_exercise_position ON workout_plan_sets (workout_plan_exercise_id, position);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS workout_session_sets;
+DROP TABLE IF EXISTS workout_sessions;
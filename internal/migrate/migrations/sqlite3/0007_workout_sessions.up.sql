@@ -0,0 +1,33 @@
+CREATE TABLE workout_sessions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  deleted_at DATETIME NULL,
+  user_id BIGINT NOT NULL,
+  workout_plan_id BIGINT,
+  workout_schedule_id BIGINT,
+  status VARCHAR(16) NOT NULL,
+  started_at DATETIME NOT NULL,
+  finished_at DATETIME NULL,
+  notes TEXT
+);
+CREATE INDEX idx_workout_sessions_deleted_at ON workout_sessions (deleted_at);
+CREATE INDEX idx_workout_sessions_user_status ON workout_sessions (user_id, status);
+
+CREATE TABLE workout_session_sets (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  workout_session_id BIGINT NOT NULL,
+  workout_plan_exercise_id BIGINT,
+  exercise_id BIGINT NOT NULL,
+  position INTEGER NOT NULL,
+  reps INTEGER NOT NULL DEFAULT 0,
+  weight REAL NOT NULL DEFAULT 0,
+  rpe REAL,
+  duration_seconds INTEGER NOT NULL DEFAULT 0,
+  notes TEXT,
+  performed_at DATETIME NOT NULL
+);
+CREATE INDEX idx_workout_session_sets_session_position ON workout_session_sets (workout_session_id, position);
+CREATE INDEX idx_workout_session_sets_exercise_id ON workout_session_sets (exercise_id);
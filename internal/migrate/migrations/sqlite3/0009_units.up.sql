@@ -0,0 +1,8 @@
+-- Loads and distances are stored in SI units with three decimals: weights in
+-- kilograms, distances and elevations in meters. SQLite has no decimal
+-- type, so existing values are only rounded to what the app reads back.
+ALTER TABLE users ADD COLUMN preferred_units VARCHAR(16) NOT NULL DEFAULT 'metric';
+
+UPDATE workout_plan_exercises SET weight = ROUND(weight, 3), distance_meters = ROUND(distance_meters, 3), elevation_meters = ROUND(elevation_meters, 3);
+UPDATE workout_plan_sets SET weight = ROUND(weight, 3), distance_meters = ROUND(distance_meters, 3), elevation_meters = ROUND(elevation_meters, 3);
+UPDATE workout_session_sets SET weight = ROUND(weight, 3), distance_meters = ROUND(distance_meters, 3), elevation_meters = ROUND(elevation_meters, 3);
@@ -0,0 +1,18 @@
+CREATE TABLE personal_records (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  user_id BIGINT NOT NULL,
+  exercise_id BIGINT NOT NULL,
+  type VARCHAR(32) NOT NULL,
+  weight REAL NOT NULL DEFAULT 0,
+  reps INTEGER NOT NULL DEFAULT 0,
+  estimated_1rm REAL NOT NULL DEFAULT 0,
+  volume REAL NOT NULL DEFAULT 0,
+  distance_meters REAL NOT NULL DEFAULT 0,
+  duration_seconds INTEGER NOT NULL DEFAULT 0,
+  workout_session_id BIGINT NOT NULL,
+  workout_session_set_id BIGINT,
+  achieved_at DATETIME NOT NULL
+);
+CREATE INDEX idx_personal_records_user_exercise_type ON personal_records (user_id, exercise_id, type);
+CREATE INDEX idx_personal_records_workout_session_id ON personal_records (workout_session_id);
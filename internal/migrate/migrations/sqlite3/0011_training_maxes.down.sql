@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS training_maxes;
+
+ALTER TABLE users DROP COLUMN one_rm_formula;
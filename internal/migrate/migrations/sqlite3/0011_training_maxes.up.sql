@@ -0,0 +1,11 @@
+ALTER TABLE users ADD COLUMN one_rm_formula VARCHAR(16) NOT NULL DEFAULT 'epley';
+
+CREATE TABLE training_maxes (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  user_id BIGINT NOT NULL,
+  exercise_id BIGINT NOT NULL,
+  weight REAL NOT NULL
+);
+CREATE UNIQUE INDEX idx_training_maxes_user_exercise ON training_maxes (user_id, exercise_id);
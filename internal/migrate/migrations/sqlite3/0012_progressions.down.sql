@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS progression_adjustments;
+DROP TABLE IF EXISTS workout_plan_progressions;
@@ -0,0 +1,27 @@
+CREATE TABLE workout_plan_progressions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  workout_plan_exercise_id BIGINT NOT NULL,
+  type VARCHAR(16) NOT NULL,
+  increment REAL NOT NULL DEFAULT 0,
+  deload_after INTEGER NOT NULL DEFAULT 0,
+  deload_percent REAL NOT NULL DEFAULT 0,
+  target_rpe REAL NOT NULL DEFAULT 0,
+  failures INTEGER NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_workout_plan_progressions_exercise ON workout_plan_progressions (workout_plan_exercise_id);
+
+CREATE TABLE progression_adjustments (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at DATETIME NULL,
+  workout_session_id BIGINT NOT NULL,
+  workout_plan_exercise_id BIGINT NOT NULL,
+  workout_schedule_id BIGINT,
+  exercise_id BIGINT NOT NULL,
+  outcome VARCHAR(16) NOT NULL,
+  previous_weight REAL NOT NULL DEFAULT 0,
+  weight REAL NOT NULL DEFAULT 0,
+  explanation VARCHAR(1000) NOT NULL
+);
+CREATE INDEX idx_progression_adjustments_workout_session_id ON progression_adjustments (workout_session_id);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_workout_schedules_program_enrollment_id;
+ALTER TABLE workout_schedules DROP COLUMN volume_percent;
+ALTER TABLE workout_schedules DROP COLUMN intensity_percent;
+ALTER TABLE workout_schedules DROP COLUMN program_week;
+ALTER TABLE workout_schedules DROP COLUMN program_enrollment_id;
+
+DROP TABLE IF EXISTS program_enrollments;
+DROP TABLE IF EXISTS program_days;
+DROP TABLE IF EXISTS program_weeks;
+DROP TABLE IF EXISTS programs;
+
+ALTER TABLE users DROP COLUMN training_days;
@@ -0,0 +1,2 @@
+ALTER TABLE program_days ADD COLUMN program_week_id BIGINT;
+CREATE INDEX idx_program_days_program_week_id ON program_days (program_week_id);
@@ -2,6 +2,15 @@ package model
 
 import "github.com/jinzhu/gorm"
 
+// Measurement kinds, saying what a set of an exercise is measured by.
+const (
+	MeasureRepsLoad         = "reps_load"
+	MeasureReps             = "reps"
+	MeasureDuration         = "duration"
+	MeasureDistanceDuration = "distance_duration"
+	MeasureDistanceLoad     = "distance_load"
+)
+
 type ExerciseCategory struct {
 	gorm.Model
 	Name string `json:"name" gorm:"unique;not null"`
@@ -13,4 +22,5 @@ type Exercise struct {
 	Description string `json:"description"`
 	Category    int    `json:"category"`
 	MuscleGroup string `json:"muscle_group" gorm:"index"`
+	Measurement string `json:"measurement" gorm:"not null;default:'reps_load'"`
 }
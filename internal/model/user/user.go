@@ -4,6 +4,13 @@ import (
 	"github.com/jinzhu/gorm"
 )
 
+// Formulas estimating a one-rep max from the weight and reps of a set.
+const (
+	FormulaEpley    = "epley"
+	FormulaBrzycki  = "brzycki"
+	FormulaLombardi = "lombardi"
+)
+
 type User struct {
 	gorm.Model
 	FirstName     string `json:"first_name"`
@@ -15,4 +22,12 @@ type User struct {
 	VerifyExpTime int64  `json:"verify_exp_time" gorm:"default:null"`
 	ResetToken    string `json:"reset_token" gorm:"default:null"`
 	ResetExpTime  int64  `json:"reset_exp_time" gorm:"default:null"`
+	// PreferredUnits is the unit system, metric or imperial, that the user's
+	// loads and distances are entered and shown in.
+	PreferredUnits string `json:"preferred_units" gorm:"not null;default:'metric'"`
+	// OneRMFormula is the formula the user's one-rep maxes are estimated with.
+	OneRMFormula string `json:"one_rm_formula" gorm:"column:one_rm_formula;not null;default:'epley'"`
+	// TrainingDays are the days of the week the user trains on, which
+	// programs are scheduled on unless an enrollment names others.
+	TrainingDays Weekdays `json:"training_days" gorm:"not null"`
 }
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// weekdayNames are the names of the days of the week by time.Weekday.
+var weekdayNames = [...]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Weekdays are days of the week by their three-letter names, mon to sun,
+// stored as a comma-separated list.
+type Weekdays []string
+
+// Has reports whether day is one of the weekdays.
+func (w Weekdays) Has(day time.Weekday) bool {
+	for _, name := range w {
+		if name == weekdayNames[day] {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Weekdays) Value() (driver.Value, error) {
+	return strings.Join(w, ","), nil
+}
+
+func (w *Weekdays) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case nil:
+	default:
+		return fmt.Errorf("cannot scan %T into Weekdays", src)
+	}
+	*w = Weekdays{}
+	if s != "" {
+		*w = strings.Split(s, ",")
+	}
+	return nil
+}
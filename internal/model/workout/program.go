@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+	userModel "workout_tracker/internal/model/user"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Program is a training block of Weeks, in each of which the workout plans
+// of its Days are done in order, unless the week has days of its own.
+type Program struct {
+	gorm.Model
+	UserId      int64  `json:"user_id" gorm:"not null"`
+	Name        string `json:"name" gorm:"not null"`
+	Description string `json:"description"`
+	Version     int64  `json:"version" gorm:"not null;default:1"`
+
+	Weeks []ProgramWeek `json:"weeks" gorm:"foreignkey:ProgramId"`
+	// Days are the days of every week. Those of single weeks have a
+	// ProgramWeekId and are only listed with their week.
+	Days []ProgramDay `json:"days" gorm:"foreignkey:ProgramId"`
+}
+
+// ProgramWeek is week Number of a program, starting at 1. Its workouts have
+// their loads scaled to IntensityPercent and their sets to VolumePercent of
+// what the plans prescribe, so that 100 leaves them as planned and a deload
+// week may set both lower. A week with Days does them instead of the days of
+// the program.
+type ProgramWeek struct {
+	ID               uint      `json:"id" gorm:"primary_key"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	ProgramId        int64     `json:"program_id" gorm:"not null"`
+	Number           int64     `json:"number" gorm:"not null"`
+	IntensityPercent float64   `json:"intensity_percent" gorm:"not null;default:100"`
+	VolumePercent    float64   `json:"volume_percent" gorm:"not null;default:100"`
+	Notes            string    `json:"notes"`
+
+	Days []ProgramDay `json:"days,omitempty" gorm:"foreignkey:ProgramWeekId;association_autocreate:false;association_autoupdate:false"`
+}
+
+// ProgramDay is the workout plan done on training day Position of every week
+// of a program, starting at 1, or only of the week ProgramWeekId.
+type ProgramDay struct {
+	ID            uint      `json:"id" gorm:"primary_key"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	ProgramId     int64     `json:"program_id" gorm:"not null"`
+	ProgramWeekId *int64    `json:"program_week_id,omitempty"`
+	Position      int64     `json:"position" gorm:"not null"`
+	WorkoutPlanId int64     `json:"workout_plan_id" gorm:"not null"`
+}
+
+// ProgramEnrollment is a run of a program by a user from StartDate, which
+// scheduled its workouts on the TrainingDays of each week.
+type ProgramEnrollment struct {
+	ID           uint               `json:"id" gorm:"primary_key"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UserId       int64              `json:"user_id" gorm:"not null"`
+	ProgramId    int64              `json:"program_id" gorm:"not null"`
+	StartDate    time.Time          `json:"start_date" gorm:"not null"`
+	TrainingDays userModel.Weekdays `json:"training_days" gorm:"not null"`
+
+	Schedules []WorkoutSchedule `json:"schedules,omitempty" gorm:"foreignkey:ProgramEnrollmentId"`
+}
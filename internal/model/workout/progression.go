@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+	"workout_tracker/pkg/units"
+)
+
+// Progression rule types.
+const (
+	ProgressionLinear = "linear"
+	ProgressionDouble = "double"
+	ProgressionRPE    = "rpe"
+)
+
+// Progression outcomes.
+const (
+	OutcomeProgressed = "progressed"
+	OutcomeHeld       = "held"
+	OutcomeReduced    = "reduced"
+	OutcomeFailed     = "failed"
+	OutcomeDeloaded   = "deloaded"
+)
+
+// WorkoutPlanProgression is the rule that moves the load of a plan exercise
+// after each finished session that follows the plan:
+//
+//	linear  adds Increment when every target was hit
+//	double  adds Increment when every target reached the top of its rep range
+//	rpe     adds Increment when the logged RPE is a point or more under
+//	        TargetRPE, and takes it off when a point or more over
+//
+// Missing a target is a failure; after DeloadAfter failures in a row the load
+// drops by DeloadPercent. Failures counts the failures so far.
+type WorkoutPlanProgression struct {
+	ID                    uint          `json:"id" gorm:"primary_key"`
+	CreatedAt             time.Time     `json:"created_at"`
+	UpdatedAt             time.Time     `json:"updated_at"`
+	WorkoutPlanExerciseId int64         `json:"workout_plan_exercise_id" gorm:"not null"`
+	Type                  string        `json:"type" gorm:"not null"`
+	Increment             units.Decimal `json:"increment" gorm:"not null" unit:"load"`
+	DeloadAfter           int64         `json:"deload_after,omitempty" gorm:"not null;default:0"`
+	DeloadPercent         float32       `json:"deload_percent,omitempty" gorm:"not null;default:0"`
+	TargetRPE             float32       `json:"target_rpe,omitempty" gorm:"column:target_rpe;not null;default:0"`
+	Failures              int64         `json:"failures" gorm:"not null;default:0"`
+}
+
+// ProgressionAdjustment records how a finished session moved the load of a
+// plan exercise, for the next scheduled workout of the plan, and why.
+type ProgressionAdjustment struct {
+	ID                    uint          `json:"id" gorm:"primary_key"`
+	CreatedAt             time.Time     `json:"created_at"`
+	WorkoutSessionId      int64         `json:"workout_session_id" gorm:"not null"`
+	WorkoutPlanExerciseId int64         `json:"workout_plan_exercise_id" gorm:"not null"`
+	WorkoutScheduleId     *int64        `json:"workout_schedule_id,omitempty"`
+	ExerciseId            int64         `json:"exercise_id" gorm:"not null"`
+	Outcome               string        `json:"outcome" gorm:"not null"`
+	PreviousWeight        units.Decimal `json:"previous_weight" unit:"load"`
+	Weight                units.Decimal `json:"weight" unit:"load"`
+	Explanation           string        `json:"explanation" gorm:"not null"`
+}
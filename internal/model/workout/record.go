@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+	"workout_tracker/pkg/units"
+)
+
+// Personal record types.
+const (
+	RecordHeaviestWeight = "heaviest_weight"
+	RecordMostReps       = "most_reps"
+	RecordEstimated1RM   = "estimated_1rm"
+	RecordSessionVolume  = "session_volume"
+	RecordFastestTime    = "fastest_time"
+)
+
+// PersonalRecord is a personal best of a user on an exercise, kept as
+// history: a record is never updated, a better one is added after it. Which
+// fields are set depends on the type:
+//
+//	heaviest_weight  weight, with the reps it was lifted for
+//	most_reps        reps, at weight; beats sets at the same or a lower weight
+//	estimated_1rm    estimated_1rm, from weight and reps
+//	session_volume   volume, the sum of reps times weight in the session
+//	fastest_time     duration_seconds, over distance or less
+type PersonalRecord struct {
+	ID                  uint          `json:"id" gorm:"primary_key"`
+	CreatedAt           time.Time     `json:"created_at"`
+	UserId              int64         `json:"user_id" gorm:"not null"`
+	ExerciseId          int64         `json:"exercise_id" gorm:"not null"`
+	Type                string        `json:"type" gorm:"not null"`
+	Weight              units.Decimal `json:"weight,omitempty" unit:"load"`
+	Reps                int64         `json:"reps,omitempty"`
+	Estimated1RM        units.Decimal `json:"estimated_1rm,omitempty" gorm:"column:estimated_1rm" unit:"load"`
+	Volume              units.Decimal `json:"volume,omitempty" unit:"load"`
+	DistanceMeters      units.Decimal `json:"distance,omitempty" unit:"distance"`
+	DurationSeconds     int64         `json:"duration_seconds,omitempty"`
+	WorkoutSessionId    int64         `json:"workout_session_id" gorm:"not null"`
+	WorkoutSessionSetId *int64        `json:"workout_session_set_id,omitempty"`
+	AchievedAt          time.Time     `json:"achieved_at" gorm:"not null"`
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+	"workout_tracker/pkg/units"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Session statuses.
+const (
+	SessionInProgress = "in_progress"
+	SessionFinished   = "finished"
+)
+
+// WorkoutSession records a workout as it was actually performed, optionally
+// following a plan or a schedule.
+type WorkoutSession struct {
+	gorm.Model
+	UserId            int64      `json:"user_id" gorm:"not null"`
+	WorkoutPlanId     *int64     `json:"workout_plan_id"`
+	WorkoutScheduleId *int64     `json:"workout_schedule_id"`
+	Status            string     `json:"status" gorm:"not null"`
+	StartedAt         time.Time  `json:"started_at" gorm:"not null"`
+	FinishedAt        *time.Time `json:"finished_at"`
+	Notes             string     `json:"notes"`
+
+	Sets        []WorkoutSessionSet `json:"sets" gorm:"foreignkey:WorkoutSessionId"`
+	WorkoutPlan *WorkoutPlan        `json:"workout_plan,omitempty" gorm:"foreignkey:WorkoutPlanId;association_autocreate:false;association_autoupdate:false"`
+	// Records are the session-wide personal records set in the session; those
+	// beaten by a single set are listed on the set.
+	Records []PersonalRecord `json:"records,omitempty" gorm:"foreignkey:WorkoutSessionId;association_autocreate:false;association_autoupdate:false"`
+	// Adjustments are the changes finishing the session made to the loads of
+	// its plan.
+	Adjustments []ProgressionAdjustment `json:"adjustments,omitempty" gorm:"foreignkey:WorkoutSessionId;association_autocreate:false;association_autoupdate:false"`
+}
+
+// WorkoutSessionSet is one set performed in a session. Position orders the
+// sets of a session starting at 1.
+type WorkoutSessionSet struct {
+	ID                    uint          `json:"id" gorm:"primary_key"`
+	CreatedAt             time.Time     `json:"created_at"`
+	UpdatedAt             time.Time     `json:"updated_at"`
+	WorkoutSessionId      int64         `json:"workout_session_id" gorm:"not null"`
+	WorkoutPlanExerciseId *int64        `json:"workout_plan_exercise_id,omitempty"`
+	ExerciseId            int64         `json:"exercise_id" gorm:"not null"`
+	Position              int64         `json:"position" gorm:"not null"`
+	Reps                  int64         `json:"reps"`
+	Weight                units.Decimal `json:"weight" unit:"load"`
+	RPE                   *float32      `json:"rpe,omitempty" gorm:"column:rpe"`
+	Notes                 string        `json:"notes"`
+	PerformedAt           time.Time     `json:"performed_at" gorm:"not null"`
+	Cardio
+
+	// Records are the personal records the set beat.
+	Records []PersonalRecord `json:"records,omitempty" gorm:"foreignkey:WorkoutSessionSetId;association_autocreate:false;association_autoupdate:false"`
+}
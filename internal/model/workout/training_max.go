@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+	"workout_tracker/pkg/units"
+)
+
+// TrainingMax is the load a user set as their max on an exercise, used in
+// place of the one-rep max estimated from their sets.
+type TrainingMax struct {
+	ID         uint          `json:"id" gorm:"primary_key"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	UserId     int64         `json:"user_id" gorm:"not null"`
+	ExerciseId int64         `json:"exercise_id" gorm:"not null"`
+	Weight     units.Decimal `json:"weight" gorm:"not null" unit:"load"`
+}
@@ -2,27 +2,137 @@ package model
 
 import (
 	"time"
+	exeModel "workout_tracker/internal/model/exercise"
+	"workout_tracker/pkg/units"
 
 	"github.com/jinzhu/gorm"
 )
 
 type WorkoutPlan struct {
 	gorm.Model
-	Name        string  `json:"name" gorm:"not null"`
-	Description string  `json:"description"`
-	UserId      int64   `json:"user_id" gorm:"foreignKey:UserId"`
-	ExerciseId  int64   `json:"exercise_id" gorm:"foreignKey:ExerciseId"`
-	Sets        int64   `json:"sets" gorm:"not null"`
-	Repetitions int64   `json:"repetitions" gorm:"not null"`
-	Weight      float32 `json:"weight" gorm:"not null"`
-	Order       int64   `json:"order" gorm:"not null"`
+	Name        string `json:"name" gorm:"not null"`
+	Description string `json:"description"`
+	UserId      int64  `json:"user_id" gorm:"foreignKey:UserId"`
+	Order       int64  `json:"order" gorm:"not null"`
+	Version     int64  `json:"version" gorm:"not null;default:1"`
+
+	Exercises []WorkoutPlanExercise `json:"exercises" gorm:"foreignkey:WorkoutPlanId"`
+	Groups    []WorkoutPlanGroup    `json:"groups,omitempty" gorm:"foreignkey:WorkoutPlanId"`
+}
+
+// Exercise group types.
+const (
+	GroupSuperset = "superset"
+	GroupCircuit  = "circuit"
+	GroupGiantSet = "giant_set"
+)
+
+// WorkoutPlanGroup groups adjacent exercises of a plan that are performed
+// back to back, resting RestSeconds after each round rather than after each
+// exercise. A circuit runs Rounds rounds; supersets and giant sets run one
+// round per set of their exercises.
+type WorkoutPlanGroup struct {
+	ID            uint      `json:"id" gorm:"primary_key"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	WorkoutPlanId int64     `json:"workout_plan_id" gorm:"not null"`
+	Type          string    `json:"type" gorm:"not null"`
+	Rounds        int64     `json:"rounds,omitempty"`
+	RestSeconds   int64     `json:"rest_seconds" gorm:"not null;default:0"`
+}
+
+// WorkoutPlanExercise is one exercise of a plan with its targets. Position
+// orders the exercises of a plan starting at 1.
+type WorkoutPlanExercise struct {
+	ID            uint          `json:"id" gorm:"primary_key"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	WorkoutPlanId int64         `json:"workout_plan_id" gorm:"not null"`
+	GroupId       *int64        `json:"group_id,omitempty"`
+	ExerciseId    int64         `json:"exercise_id" gorm:"not null"`
+	Position      int64         `json:"position" gorm:"not null"`
+	Sets          int64         `json:"sets" gorm:"not null"`
+	Repetitions   int64         `json:"repetitions" gorm:"not null"`
+	Weight        units.Decimal `json:"weight" gorm:"not null" unit:"load"`
+	RestSeconds   int64         `json:"rest_seconds" gorm:"not null;default:0"`
+	Notes         string        `json:"notes"`
+	Cardio
+
+	Exercise      *exeModel.Exercise      `json:"exercise,omitempty" gorm:"foreignkey:ExerciseId;association_autocreate:false;association_autoupdate:false"`
+	Prescriptions []WorkoutPlanSet        `json:"prescriptions,omitempty" gorm:"foreignkey:WorkoutPlanExerciseId"`
+	Progression   *WorkoutPlanProgression `json:"progression,omitempty" gorm:"foreignkey:WorkoutPlanExerciseId"`
+}
+
+// Set prescription types.
+const (
+	SetWarmup  = "warmup"
+	SetWorking = "working"
+	SetDrop    = "drop"
+	SetFailure = "failure"
+	SetAMRAP   = "amrap"
+)
+
+// Cardio holds the time and distance measures of a target or a performed
+// set, stored in seconds, meters and seconds per kilometer. HeartRate is the
+// average in beats per minute. Measures left at zero are not tracked.
+type Cardio struct {
+	DurationSeconds  int64         `json:"duration_seconds,omitempty" gorm:"not null;default:0"`
+	DistanceMeters   units.Decimal `json:"distance,omitempty" gorm:"not null;default:0" unit:"distance"`
+	PaceSecondsPerKm int64         `json:"pace,omitempty" gorm:"not null;default:0" unit:"pace"`
+	ElevationMeters  units.Decimal `json:"elevation,omitempty" gorm:"not null;default:0" unit:"elevation"`
+	HeartRate        int64         `json:"heart_rate,omitempty" gorm:"not null;default:0"`
+	Calories         int64         `json:"calories,omitempty" gorm:"not null;default:0"`
+}
+
+// WorkoutPlanSet prescribes one set of a plan exercise. Reps is the target,
+// or the bottom of a rep range ending at MaxReps. The load is an absolute
+// Weight or a percentage of the one-rep max, optionally capped by an RPE or
+// RIR target. Position orders the sets of an exercise starting at 1.
+type WorkoutPlanSet struct {
+	ID                    uint           `json:"id" gorm:"primary_key"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+	WorkoutPlanExerciseId int64          `json:"workout_plan_exercise_id" gorm:"not null"`
+	Position              int64          `json:"position" gorm:"not null"`
+	Type                  string         `json:"type" gorm:"not null"`
+	Reps                  int64          `json:"reps"`
+	MaxReps               int64          `json:"max_reps,omitempty"`
+	Weight                *units.Decimal `json:"weight,omitempty" unit:"load"`
+	Percent1RM            *float32       `json:"percent_1rm,omitempty" gorm:"column:percent_1rm"`
+	RPE                   *float32       `json:"rpe,omitempty" gorm:"column:rpe"`
+	RIR                   *int64         `json:"rir,omitempty" gorm:"column:rir"`
+	RestSeconds           int64          `json:"rest_seconds" gorm:"not null;default:0"`
+	Cardio
 }
 
+// Schedule statuses.
+const (
+	StatusScheduled = "scheduled"
+	StatusCompleted = "completed"
+	StatusSkipped   = "skipped"
+	StatusCancelled = "cancelled"
+)
+
+// WorkoutSchedule is a workout of a plan scheduled on a date. Schedules made
+// by enrolling in a program record the enrollment, the program week and the
+// week's modifiers, which are zero for schedules made on their own.
 type WorkoutSchedule struct {
 	gorm.Model
-	UserId        int64     `json:"user_id" gorm:"foreignKey:UserId"`
-	WorkoutPlanId int64     `json:"workout_plan_id" gorm:"foreignKey:WorkoutPlanId"`
-	ScheduledDate time.Time `json:"scheduled_date" gorm:"not null"`
-	Status        string    `json:"status" gorm:"default:'scheduled'"`
-	CompletedDate time.Time `json:"completed_date"`
+	UserId              int64     `json:"user_id" gorm:"foreignKey:UserId"`
+	WorkoutPlanId       int64     `json:"workout_plan_id" gorm:"foreignKey:WorkoutPlanId"`
+	ScheduledDate       time.Time `json:"scheduled_date" gorm:"not null"`
+	Status              string    `json:"status" gorm:"default:'scheduled'"`
+	CompletedDate       time.Time `json:"completed_date"`
+	Version             int64     `json:"version" gorm:"not null;default:1"`
+	ProgramEnrollmentId *int64    `json:"program_enrollment_id,omitempty"`
+	ProgramWeek         int64     `json:"program_week,omitempty" gorm:"not null;default:0"`
+	IntensityPercent    float64   `json:"intensity_percent,omitempty" gorm:"not null;default:0"`
+	VolumePercent       float64   `json:"volume_percent,omitempty" gorm:"not null;default:0"`
+}
+
+// Modified reports whether the program week of the schedule changes the
+// loads or sets of its plan.
+func (s WorkoutSchedule) Modified() bool {
+	return s.IntensityPercent != 0 && s.IntensityPercent != 100 ||
+		s.VolumePercent != 0 && s.VolumePercent != 100
 }
@@ -0,0 +1,195 @@
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/pkg/metrics"
+	"workout_tracker/pkg/middleware"
+	"workout_tracker/pkg/tracing"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Purger permanently deletes workout plans, schedules and programs that have
+// been soft-deleted for longer than the retention period, along with expired
+// idempotency keys. It runs once on start and then every interval until
+// stopped.
+type Purger struct {
+	db        *gorm.DB
+	retention time.Duration
+	interval  time.Duration
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewPurger(db *gorm.DB, retention, interval time.Duration) *Purger {
+	return &Purger{db: db, retention: retention, interval: interval}
+}
+
+// Start launches the purge loop. When retention is not positive deleted rows
+// are kept forever, and the loop only purges expired idempotency keys.
+func (p *Purger) Start(ctx context.Context) error {
+	if p.retention <= 0 {
+		slog.Info("Trash purge disabled")
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.run()
+	return nil
+}
+
+// Stop ends the purge loop, waiting for a purge in progress to finish or
+// ctx to expire.
+func (p *Purger) Stop(ctx context.Context) error {
+	if p.stop == nil {
+		return nil
+	}
+	close(p.stop)
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Purger) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if p.retention > 0 {
+			if err := p.Purge(context.Background()); err != nil {
+				slog.Error("Failed to purge trash", "error", err)
+			}
+		}
+		if err := p.PurgeIdempotencyKeys(context.Background()); err != nil {
+			slog.Error("Failed to purge expired idempotency keys", "error", err)
+		}
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge hard-deletes the schedules, plans and programs deleted before the
+// retention period, each kind in its own transaction so that a failure never
+// leaves half of a plan or program behind. Children go first so none is left
+// pointing at a purged row.
+func (p *Purger) Purge(ctx context.Context) error {
+	cutoff := time.Now().Add(-p.retention)
+	db := p.db.Set(tracing.DBContextKey, ctx).Unscoped()
+
+	var schedules, plans, programs int64
+	if err := db.Transaction(func(tx *gorm.DB) (err error) {
+		schedules, err = purgeSchedules(tx, cutoff)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := db.Transaction(func(tx *gorm.DB) (err error) {
+		plans, err = purgePlans(tx, cutoff)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := db.Transaction(func(tx *gorm.DB) (err error) {
+		programs, err = purgePrograms(tx, cutoff)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	metrics.TrashPurged.WithLabelValues("workout_schedules").Add(float64(schedules))
+	metrics.TrashPurged.WithLabelValues("workout_plans").Add(float64(plans))
+	metrics.TrashPurged.WithLabelValues("programs").Add(float64(programs))
+	if schedules > 0 || plans > 0 || programs > 0 {
+		slog.InfoContext(ctx, "Purged trash", "workout_plans", plans, "workout_schedules", schedules,
+			"programs", programs, "deleted_before", cutoff)
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeys deletes the idempotency keys whose stored response
+// has expired.
+func (p *Purger) PurgeIdempotencyKeys(ctx context.Context) error {
+	purged, err := middleware.PurgeExpiredIdempotencyKeys(p.db.Set(tracing.DBContextKey, ctx), time.Now())
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		slog.InfoContext(ctx, "Purged expired idempotency keys", "idempotency_keys", purged)
+	}
+	return nil
+}
+
+// purgeSchedules deletes the schedules deleted before cutoff. Sessions and
+// progression adjustments of those schedules are kept as history, detached
+// from the schedule.
+func purgeSchedules(db *gorm.DB, cutoff time.Time) (int64, error) {
+	purged := db.Model(&model.WorkoutSchedule{}).Select("id").Where("deleted_at < ?", cutoff).QueryExpr()
+	if err := db.Model(&model.WorkoutSession{}).Where("workout_schedule_id IN (?)", purged).
+		UpdateColumn("workout_schedule_id", nil).Error; err != nil {
+		return 0, err
+	}
+	if err := db.Model(&model.ProgressionAdjustment{}).Where("workout_schedule_id IN (?)", purged).
+		UpdateColumn("workout_schedule_id", nil).Error; err != nil {
+		return 0, err
+	}
+	result := db.Where("deleted_at < ?", cutoff).Delete(&model.WorkoutSchedule{})
+	return result.RowsAffected, result.Error
+}
+
+// purgePlans deletes the plans deleted before cutoff with their exercises,
+// set prescriptions, progression rules, groups and the adjustments those
+// rules made. Sessions done from the plans are kept as history, detached from
+// the plan and its exercises; the personal records set in them point at the
+// sessions and stay valid.
+func purgePlans(db *gorm.DB, cutoff time.Time) (int64, error) {
+	purged := db.Model(&model.WorkoutPlan{}).Select("id").Where("deleted_at < ?", cutoff).QueryExpr()
+	purgedExercises := db.Model(&model.WorkoutPlanExercise{}).Select("id").Where("workout_plan_id IN (?)", purged).QueryExpr()
+	if err := db.Model(&model.WorkoutSession{}).Where("workout_plan_id IN (?)", purged).
+		UpdateColumn("workout_plan_id", nil).Error; err != nil {
+		return 0, err
+	}
+	if err := db.Model(&model.WorkoutSessionSet{}).Where("workout_plan_exercise_id IN (?)", purgedExercises).
+		UpdateColumn("workout_plan_exercise_id", nil).Error; err != nil {
+		return 0, err
+	}
+	for _, child := range []interface{}{&model.ProgressionAdjustment{}, &model.WorkoutPlanSet{}, &model.WorkoutPlanProgression{}} {
+		if err := db.Where("workout_plan_exercise_id IN (?)", purgedExercises).Delete(child).Error; err != nil {
+			return 0, err
+		}
+	}
+	if err := db.Where("workout_plan_id IN (?)", purged).Delete(&model.WorkoutPlanGroup{}).Error; err != nil {
+		return 0, err
+	}
+	if err := db.Where("workout_plan_id IN (?)", purged).Delete(&model.WorkoutPlanExercise{}).Error; err != nil {
+		return 0, err
+	}
+	result := db.Where("deleted_at < ?", cutoff).Delete(&model.WorkoutPlan{})
+	return result.RowsAffected, result.Error
+}
+
+// purgePrograms deletes the programs deleted before cutoff with their weeks,
+// days and enrollments. Workouts scheduled by an enrollment are kept, as they
+// are when the program is deleted, detached from the enrollment.
+func purgePrograms(db *gorm.DB, cutoff time.Time) (int64, error) {
+	purged := db.Model(&model.Program{}).Select("id").Where("deleted_at < ?", cutoff).QueryExpr()
+	purgedEnrollments := db.Model(&model.ProgramEnrollment{}).Select("id").Where("program_id IN (?)", purged).QueryExpr()
+	if err := db.Model(&model.WorkoutSchedule{}).Where("program_enrollment_id IN (?)", purgedEnrollments).
+		UpdateColumn("program_enrollment_id", nil).Error; err != nil {
+		return 0, err
+	}
+	for _, child := range []interface{}{&model.ProgramEnrollment{}, &model.ProgramWeek{}, &model.ProgramDay{}} {
+		if err := db.Where("program_id IN (?)", purged).Delete(child).Error; err != nil {
+			return 0, err
+		}
+	}
+	result := db.Where("deleted_at < ?", cutoff).Delete(&model.Program{})
+	return result.RowsAffected, result.Error
+}
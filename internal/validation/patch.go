@@ -0,0 +1,122 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"workout_tracker/pkg/apperror"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gin-gonic/gin"
+)
+
+// Patch media types accepted by BindPatch. Plain application/json is treated
+// as a merge patch.
+const (
+	MergePatchType = "application/merge-patch+json"
+	JSONPatchType  = "application/json-patch+json"
+)
+
+// BindPatch applies the request body as a patch to obj, which must point to
+// a DTO holding the resource's current values, and validates the result.
+// The body is an RFC 7396 JSON Merge Patch, or an RFC 6902 JSON Patch when
+// sent as application/json-patch+json.
+func BindPatch(c *gin.Context, obj interface{}) *apperror.Error {
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType == "" {
+		mediaType = MergePatchType
+	}
+	if mediaType != MergePatchType && mediaType != JSONPatchType && mediaType != "application/json" {
+		return apperror.New(http.StatusUnsupportedMediaType, "unsupported_media_type", "Use "+MergePatchType+" or "+JSONPatchType)
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return apperror.BadRequest("invalid_request_body", "Invalid request body")
+	}
+	if len(body) == 0 {
+		return apperror.BadRequest("invalid_request_body", "Request body is required")
+	}
+	return Patch(Context(c), obj, mediaType, body)
+}
+
+// Patch applies patch, of the given media type, to obj and validates the
+// result. Only fields of the DTO can be patched; any other field is
+// reported as a validation error. obj is left untouched unless the patched
+// document decodes.
+func Patch(ctx context.Context, obj interface{}, mediaType string, patch []byte) *apperror.Error {
+	current, err := json.Marshal(obj)
+	if err != nil {
+		return apperror.Internal("Failed to encode resource", err)
+	}
+	patched, patchErr := applyPatch(mediaType, current, patch)
+	if patchErr != nil {
+		return patchErr
+	}
+
+	if fields := unknownFields(current, patched); len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+
+	// Decode into a zero value so fields removed by the patch end up empty
+	// rather than keeping their current value.
+	target := reflect.New(reflect.TypeOf(obj).Elem())
+	if err := json.Unmarshal(patched, target.Interface()); err != nil {
+		return decodeError(err)
+	}
+	reflect.ValueOf(obj).Elem().Set(target.Elem())
+	return Struct(ctx, obj)
+}
+
+func applyPatch(mediaType string, doc, body []byte) ([]byte, *apperror.Error) {
+	if mediaType != JSONPatchType {
+		if !json.Valid(body) || bytes.TrimSpace(body)[0] != '{' {
+			return nil, apperror.BadRequest("invalid_patch", "A merge patch must be a JSON object")
+		}
+		patched, err := jsonpatch.MergePatch(doc, body)
+		if err != nil {
+			return nil, apperror.BadRequest("invalid_patch", "Invalid merge patch")
+		}
+		return patched, nil
+	}
+
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		return nil, apperror.BadRequest("invalid_patch", "A JSON Patch must be an array of operations")
+	}
+	patched, err := patch.Apply(doc)
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, apperror.Conflict("patch_test_failed", "A test operation did not match the current value")
+		}
+		return nil, apperror.New(http.StatusUnprocessableEntity, "patch_failed", err.Error())
+	}
+	return patched, nil
+}
+
+// unknownFields reports top-level fields of patched that the original
+// document does not have, which a patch is not allowed to introduce.
+func unknownFields(original, patched []byte) []apperror.FieldError {
+	var before, after map[string]json.RawMessage
+	if json.Unmarshal(original, &before) != nil || json.Unmarshal(patched, &after) != nil {
+		return nil
+	}
+	var fields []apperror.FieldError
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			fields = append(fields, apperror.FieldError{
+				Field:   name,
+				Code:    "not_patchable",
+				Message: "cannot be changed",
+			})
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+	return fields
+}
@@ -0,0 +1,332 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"workout_tracker/internal/config"
+	exeModel "workout_tracker/internal/model/exercise"
+	model "workout_tracker/internal/model/workout"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/tracing"
+	"workout_tracker/pkg/units"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/jinzhu/gorm"
+)
+
+// UserIDKey is the gin context key handlers set to the authenticated user's
+// id before binding, for validators that check ownership.
+const UserIDKey = "user_id"
+
+// UnitSystemKey is the gin context key handlers set to the units.System of
+// the request before binding, for validators capping loads and distances.
+// Requests without one are taken to be metric.
+const UnitSystemKey = "unit_system"
+
+type (
+	userKey   struct{}
+	dbKey     struct{}
+	systemKey struct{}
+)
+
+// embeddedPrefix marks embedded structs in a field's namespace, so their
+// fields are reported as if declared on the outer struct.
+const embeddedPrefix = "~"
+
+var validate = newValidator()
+
+// newValidator reads rules from `binding` struct tags, as gin does, and
+// reports fields by their JSON or form name. Decimals are checked by their
+// value, so that max=2000 caps a load at 2000 rather than 2 kg. Custom rules:
+//
+//	exercise      id of an exercise in the (non-deleted) catalog
+//	workout_plan  id of a workout plan owned by the user set under UserIDKey
+//	max_<kind>    a quantity of a units kind, such as max_load=2000, sent in
+//	              the units of the request, at most the limit in SI units
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		if field.Anonymous {
+			return embeddedPrefix + field.Name
+		}
+		for _, tag := range []string{"json", "form"} {
+			name := strings.Split(field.Tag.Get(tag), ",")[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return field.Interface().(units.Decimal).Float64()
+	}, units.Decimal(0))
+	v.RegisterValidationCtx("exercise", exerciseExists)
+	v.RegisterValidationCtx("workout_plan", workoutPlanOwned)
+	for _, kind := range []string{units.Load, units.Distance, units.Elevation, units.Pace} {
+		v.RegisterValidationCtx("max_"+kind, maxQuantity(kind))
+	}
+	return v
+}
+
+// WithDB returns a copy of ctx whose validators query through db, so that
+// rows written earlier in the same transaction are visible to them.
+func WithDB(ctx context.Context, db *gorm.DB) context.Context {
+	return context.WithValue(ctx, dbKey{}, db)
+}
+
+func database(ctx context.Context) *gorm.DB {
+	if db, ok := ctx.Value(dbKey{}).(*gorm.DB); ok {
+		return db.Set(tracing.DBContextKey, ctx)
+	}
+	return config.GetDBContext(ctx)
+}
+
+// maxQuantity returns the rule capping a quantity of the given kind at a
+// limit in SI units. The limit is compared in the units of the request, so
+// that it is the same load or distance for every user.
+func maxQuantity(kind string) validator.FuncCtx {
+	return func(ctx context.Context, fl validator.FieldLevel) bool {
+		limit, err := strconv.ParseFloat(fl.Param(), 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid max_%s limit %q", kind, fl.Param()))
+		}
+		field := fl.Field()
+		switch {
+		case field.CanFloat():
+			return field.Float() <= quantityLimit(ctx, kind, limit)
+		case field.CanInt():
+			return float64(field.Int()) <= quantityLimit(ctx, kind, limit)
+		}
+		return false
+	}
+}
+
+// quantityLimit returns limit, in SI units, in the units of the request.
+func quantityLimit(ctx context.Context, kind string, limit float64) float64 {
+	system, ok := ctx.Value(systemKey{}).(units.System)
+	if !ok {
+		system = units.Metric
+	}
+	return units.Convert(system, kind, units.NewDecimal(limit)).Float64()
+}
+
+func exerciseExists(ctx context.Context, fl validator.FieldLevel) bool {
+	var count int
+	err := database(ctx).Model(&exeModel.Exercise{}).Where("id = ?", fl.Field().Int()).Count(&count).Error
+	return err == nil && count > 0
+}
+
+func workoutPlanOwned(ctx context.Context, fl validator.FieldLevel) bool {
+	userId, ok := ctx.Value(userKey{}).(int64)
+	if !ok {
+		return false
+	}
+	var count int
+	err := database(ctx).Model(&model.WorkoutPlan{}).Where("id = ? AND user_id = ?", fl.Field().Int(), userId).Count(&count).Error
+	return err == nil && count > 0
+}
+
+// BindJSON decodes the request body into obj and validates it, reporting
+// every invalid field at once. It returns nil when obj is valid.
+func BindJSON(c *gin.Context, obj interface{}) *apperror.Error {
+	if err := json.NewDecoder(c.Request.Body).Decode(obj); err != nil {
+		if errors.Is(err, io.EOF) {
+			return apperror.BadRequest("invalid_request_body", "Request body is required")
+		}
+		return decodeError(err)
+	}
+	return Struct(Context(c), obj)
+}
+
+// Decode unmarshals data into obj and validates it, for JSON that is part of
+// a larger request such as one operation of a bulk request.
+func Decode(ctx context.Context, data []byte, obj interface{}) *apperror.Error {
+	if err := json.Unmarshal(data, obj); err != nil {
+		return decodeError(err)
+	}
+	return Struct(ctx, obj)
+}
+
+// decodeError reports a JSON value of the wrong type as a field error and
+// anything else as a malformed body. The decoder does not name the field
+// when a custom unmarshaler such as units.Decimal rejects the value, so that
+// is reported against the body instead.
+func decodeError(err error) *apperror.Error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if typeErr.Field == "" {
+			return apperror.BadRequest("invalid_type", "Invalid request body: "+typeErr.Value+" must be a number")
+		}
+		return apperror.Validation(apperror.FieldError{
+			Field:   typeErr.Field,
+			Code:    "invalid_type",
+			Message: "must be a " + typeErr.Type.String(),
+		})
+	}
+	return apperror.BadRequest("invalid_request_body", "Invalid request body")
+}
+
+// BindQuery maps the query string into obj using its `form` tags and
+// validates it.
+func BindQuery(c *gin.Context, obj interface{}) *apperror.Error {
+	if err := binding.MapFormWithTag(obj, c.Request.URL.Query(), "form"); err != nil {
+		return apperror.BadRequest("invalid_query", "Invalid query parameters")
+	}
+	return Struct(Context(c), obj)
+}
+
+// Context returns the request context, carrying the user id set under
+// UserIDKey for ownership validators and the unit system set under
+// UnitSystemKey for caps on quantities.
+func Context(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if userId, ok := c.Get(UserIDKey); ok {
+		ctx = context.WithValue(ctx, userKey{}, userId)
+	}
+	if system, ok := c.Get(UnitSystemKey); ok {
+		ctx = context.WithValue(ctx, systemKey{}, system)
+	}
+	return ctx
+}
+
+// Struct validates obj, converting every failed rule into a field error.
+func Struct(ctx context.Context, obj interface{}) *apperror.Error {
+	err := validate.StructCtx(ctx, obj)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return apperror.Internal("Failed to validate request", err)
+	}
+	fields := make([]apperror.FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		code := fe.Tag()
+		if strings.HasPrefix(code, "max_") {
+			// Caps on quantities are reported like any other maximum.
+			code = "max"
+		}
+		fields = append(fields, apperror.FieldError{
+			Field:   fieldPath(fe),
+			Code:    code,
+			Message: message(ctx, fe),
+		})
+	}
+	return apperror.Validation(fields...)
+}
+
+// fieldPath drops the struct name and any embedded structs from the
+// namespace, so a nested field is reported as entries[0].sets rather than
+// Request.entries[0].sets.
+func fieldPath(fe validator.FieldError) string {
+	segments := strings.Split(fe.Namespace(), ".")
+	if len(segments) == 1 {
+		return fe.Field()
+	}
+	path := make([]string, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		if !strings.HasPrefix(segment, embeddedPrefix) {
+			path = append(path, segment)
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+func message(ctx context.Context, fe validator.FieldError) string {
+	if kind, ok := strings.CutPrefix(fe.Tag(), "max_"); ok {
+		system, _ := ctx.Value(systemKey{}).(units.System)
+		limit, _ := strconv.ParseFloat(fe.Param(), 64)
+		return fmt.Sprintf("must be at most %s %s", strconv.FormatFloat(quantityLimit(ctx, kind, limit), 'f', -1, 64), units.Symbol(system, kind))
+	}
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_without", "required_without_all":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		switch fe.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		case reflect.Slice, reflect.Map:
+			return fmt.Sprintf("must contain at least %s items", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		switch fe.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		case reflect.Slice, reflect.Map:
+			return fmt.Sprintf("must contain at most %s items", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be at least " + fe.Param()
+	case "lt":
+		return "must be less than " + fe.Param()
+	case "lte":
+		return "must be at most " + fe.Param()
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "eqfield":
+		return "must match " + snakeCase(fe.Param())
+	case "gtfield":
+		return "must be after " + snakeCase(fe.Param())
+	case "gtefield":
+		if fe.Kind() == reflect.Struct {
+			return "must be on or after " + snakeCase(fe.Param())
+		}
+		return "must be at least " + snakeCase(fe.Param())
+	case "unique":
+		return "must not contain duplicates"
+	case "datetime":
+		return "must be a date formatted as YYYY-MM-DD"
+	case "excluded_with":
+		fields := strings.Fields(fe.Param())
+		for i, field := range fields {
+			fields[i] = snakeCase(field)
+		}
+		return "cannot be combined with " + strings.Join(fields, ", ")
+	case "exercise":
+		return "must reference an existing exercise"
+	case "workout_plan":
+		return "must reference one of your workout plans"
+	default:
+		return "is invalid"
+	}
+}
+
+// snakeCase turns a struct field name referenced by a cross-field rule, such
+// as NewPassword, into its JSON spelling, new_password. Acronyms stay whole
+// and digits start a new word, so Percent1RM becomes percent_1rm.
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 {
+			prev := runes[i-1]
+			startsWord := unicode.IsUpper(r) && unicode.IsLower(prev) ||
+				unicode.IsDigit(r) && unicode.IsLetter(prev)
+			if startsWord {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
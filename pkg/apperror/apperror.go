@@ -0,0 +1,171 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+const ContentType = "application/problem+json"
+
+// Error is an RFC 7807 problem detail. Code is the stable, machine-readable
+// identifier clients should match on; Detail is safe to show to users. The
+// cause is logged but never rendered.
+type Error struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Code      string       `json:"code"`
+	Detail    string       `json:"detail"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	cause     error
+}
+
+// FieldError describes one invalid input field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Code + ": " + e.Detail + ": " + e.cause.Error()
+	}
+	return e.Code + ": " + e.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Wrap attaches the internal cause of the error for logging.
+func (e *Error) Wrap(cause error) *Error {
+	e.cause = cause
+	return e
+}
+
+// WithFields attaches field-level validation details.
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	e.Errors = append(e.Errors, fields...)
+	return e
+}
+
+func New(status int, code, detail string) *Error {
+	return &Error{
+		Type:   "urn:kinetic-core:problem:" + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+func BadRequest(code, detail string) *Error {
+	return New(http.StatusBadRequest, code, detail)
+}
+
+func Unauthorized() *Error {
+	return New(http.StatusUnauthorized, "unauthorized", "Unauthorized")
+}
+
+func NotFound(code, detail string) *Error {
+	return New(http.StatusNotFound, code, detail)
+}
+
+func Conflict(code, detail string) *Error {
+	return New(http.StatusConflict, code, detail)
+}
+
+// Validation reports invalid input fields, all at once.
+func Validation(fields ...FieldError) *Error {
+	return New(http.StatusUnprocessableEntity, "validation_failed", "One or more fields are invalid").WithFields(fields...)
+}
+
+// Internal hides cause behind a generic detail.
+func Internal(detail string, cause error) *Error {
+	return New(http.StatusInternalServerError, "internal_error", detail).Wrap(cause)
+}
+
+// Database maps a database error: missing records become 404, unique
+// constraint violations 409, and anything else a 500 with detail.
+func Database(cause error, detail string) *Error {
+	switch {
+	case gorm.IsRecordNotFoundError(cause):
+		return NotFound("not_found", "Resource not found").Wrap(cause)
+	case isUniqueViolation(cause):
+		return Conflict("conflict", "Resource already exists").Wrap(cause)
+	default:
+		return Internal(detail, cause)
+	}
+}
+
+// Lookup maps the error of fetching a single record: a missing record becomes
+// a 404 with the given code and detail, anything else a 500.
+func Lookup(cause error, code, detail string) *Error {
+	if gorm.IsRecordNotFoundError(cause) {
+		return NotFound(code, detail).Wrap(cause)
+	}
+	return Internal("Internal server error", cause)
+}
+
+// Unique maps the error of a write guarded by a unique index: a violation
+// becomes a 409 with the given code and detail, anything else a 500.
+func Unique(cause error, code, detail string) *Error {
+	if isUniqueViolation(cause) {
+		return Conflict(code, detail).Wrap(cause)
+	}
+	return Internal("Internal server error", cause)
+}
+
+func isUniqueViolation(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1062
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+	return false
+}
+
+// From converts any error into a problem. Errors that are not already a
+// problem are treated as database errors.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Database(err, "Internal server error")
+}
+
+// Abort renders err as application/problem+json and stops the handler
+// chain. Server errors are logged with their cause.
+func Abort(c *gin.Context, err error) {
+	problem := *From(err)
+	problem.Instance = c.Request.URL.Path
+	problem.RequestID = c.GetString("request_id")
+
+	if problem.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(c.Request.Context(), problem.Detail, "code", problem.Code, "error", problem.cause)
+	}
+	c.Error(err)
+
+	body, _ := json.Marshal(problem)
+	c.Abort()
+	c.Data(problem.Status, ContentType, body)
+}
@@ -0,0 +1,130 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"workout_tracker/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FromVersion returns the strong entity tag for a resource version.
+func FromVersion(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ForRepresentation returns the entity tag of one representation of a
+// resource version, such as its body in imperial units. The inputs that shape
+// the body are hashed into a suffix after the version, so that a cached body
+// is revalidated when any of them changes. If-Match compares only the
+// version: the inputs change how a version is shown, not which one it is.
+func ForRepresentation(version int64, inputs ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(inputs, "\x00")))
+	return `"` + strconv.FormatInt(version, 10) + "-" + hex.EncodeToString(hash[:8]) + `"`
+}
+
+// Set adds the ETag header for version to the response.
+func Set(c *gin.Context, version int64) {
+	c.Header("ETag", FromVersion(version))
+}
+
+// NotModified sets the ETag header and, when it matches If-None-Match,
+// responds 304 Not Modified. Handlers return early when it reports true.
+func NotModified(c *gin.Context, version int64) bool {
+	return NotModifiedTag(c, FromVersion(version))
+}
+
+// NotModifiedTag is NotModified for a tag from ForRepresentation.
+func NotModifiedTag(c *gin.Context, tag string) bool {
+	c.Header("ETag", tag)
+	header := c.GetHeader("If-None-Match")
+	if header == "" || !matches(header, tag, true) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// IfMatch checks the request's If-Match header against the current version.
+// A request without If-Match, or with If-Match: *, is unconditional. A
+// request whose If-Match does not list the current version fails with 412
+// Precondition Failed.
+func IfMatch(c *gin.Context, current int64) *apperror.Error {
+	return CheckIfMatch(c.GetHeader("If-Match"), current)
+}
+
+// CheckIfMatch is IfMatch for an If-Match value that did not come from a
+// header, such as the if_match of a bulk operation.
+func CheckIfMatch(ifMatch string, current int64) *apperror.Error {
+	if !conditional(ifMatch) {
+		return nil
+	}
+	if !matches(ifMatch, FromVersion(current), false) {
+		return PreconditionFailed()
+	}
+	return nil
+}
+
+func conditional(ifMatch string) bool {
+	ifMatch = strings.TrimSpace(ifMatch)
+	return ifMatch != "" && ifMatch != "*"
+}
+
+// PreconditionFailed is returned when the resource changed since the client
+// last read it.
+func PreconditionFailed() *apperror.Error {
+	return apperror.New(http.StatusPreconditionFailed, "precondition_failed", "The resource was modified since it was last retrieved; fetch it again and retry")
+}
+
+// matches reports whether tag is listed in an If-Match or If-None-Match
+// header. Weak comparison ignores the W/ prefix, as If-None-Match requires;
+// If-Match uses strong comparison, so weak tags never match, and compares
+// tags from ForRepresentation by their version.
+func matches(header, tag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = candidate[2:]
+		} else if !weak {
+			candidate = versionOf(candidate)
+		}
+		if candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ConcurrentUpdate is returned when a write finds the resource at a newer
+// version than the one read moments before: 412 if the client made the
+// request conditional, 409 otherwise.
+func ConcurrentUpdate(c *gin.Context) *apperror.Error {
+	return Stale(c.GetHeader("If-Match"))
+}
+
+// Stale is ConcurrentUpdate for an If-Match value that did not come from a
+// header.
+func Stale(ifMatch string) *apperror.Error {
+	if conditional(ifMatch) {
+		return PreconditionFailed()
+	}
+	return apperror.Conflict("edit_conflict", "The resource was modified concurrently; fetch it again and retry")
+}
+
+// versionOf strips the representation suffix from a tag made by
+// ForRepresentation, leaving the tag of its version.
+func versionOf(tag string) string {
+	if i := strings.IndexByte(tag, '-'); i > 0 && strings.HasPrefix(tag, `"`) {
+		return tag[:i] + `"`
+	}
+	return tag
+}
@@ -0,0 +1,65 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Hook is a subsystem started and stopped together with the application.
+// Either function may be nil.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle starts hooks in the order they were appended and stops them in
+// reverse order, so a subsystem is stopped before the ones it depends on.
+type Lifecycle struct {
+	hooks   []Hook
+	started int
+}
+
+func New() *Lifecycle {
+	return &Lifecycle{}
+}
+
+func (l *Lifecycle) Append(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// Start runs every OnStart in order. If one fails, the hooks already started
+// are stopped and the error is returned.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	for _, hook := range l.hooks {
+		if hook.OnStart != nil {
+			if err := hook.OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("starting %s: %w", hook.Name, err)
+				return errors.Join(startErr, l.Stop(ctx))
+			}
+		}
+		l.started++
+		slog.Info("Started", "hook", hook.Name)
+	}
+	return nil
+}
+
+// Stop runs OnStop for every started hook in reverse order. It keeps going
+// when a hook fails or ctx expires and returns all errors joined.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	var errs []error
+	for ; l.started > 0; l.started-- {
+		hook := l.hooks[l.started-1]
+		if hook.OnStop == nil {
+			continue
+		}
+		if err := hook.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stopping %s: %w", hook.Name, err))
+			continue
+		}
+		slog.Info("Stopped", "hook", hook.Name)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,43 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// GormLogger forwards the messages of GORM to the default slog logger at
+// debug level. Statements are logged without their bound values, which may
+// hold secrets. Query errors are returned to, and reported by, the caller.
+type GormLogger struct{}
+
+// Print implements the logger of GORM. The first value is the kind of
+// message: sql, log and error messages then carry the file and line they come
+// from, while callback registrations carry a single line of text.
+func (GormLogger) Print(values ...interface{}) {
+	if len(values) < 2 {
+		return
+	}
+	logger := slog.Default()
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	kind := fmt.Sprint(values[0])
+	if len(values) == 2 {
+		logger.Debug(strings.TrimPrefix(fmt.Sprint(values[1]), "["+kind+"] "), "kind", kind)
+		return
+	}
+
+	attrs := []any{"kind", kind, "source", values[1]}
+	if kind == "sql" && len(values) == 6 {
+		if duration, ok := values[2].(time.Duration); ok {
+			attrs = append(attrs, "duration_ms", float64(duration.Microseconds())/1000)
+		}
+		attrs = append(attrs, "rows", values[5])
+		logger.Debug(strings.TrimSpace(fmt.Sprint(values[3])), attrs...)
+		return
+	}
+	logger.Debug(strings.TrimSpace(fmt.Sprintln(values[2:]...)), attrs...)
+}
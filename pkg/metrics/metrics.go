@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "kinetic_core"
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	RateLimitRejections = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_rejections_total",
+		Help:      "Requests rejected by the rate limiter.",
+	})
+
+	EmailsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_sent_total",
+		Help:      "Emails sent by result (success or failure).",
+	}, []string{"result"})
+
+	WorkoutsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "workouts_created_total",
+		Help:      "Workout plans created.",
+	})
+
+	SchedulesCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "schedules_created_total",
+		Help:      "Workout schedules created.",
+	})
+
+	SchedulesCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "schedules_completed_total",
+		Help:      "Workout schedules marked completed.",
+	})
+
+	ProgramEnrollments = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "program_enrollments_total",
+		Help:      "Enrollments in training programs.",
+	})
+
+	SessionsFinished = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sessions_finished_total",
+		Help:      "Workout sessions finished.",
+	})
+
+	SetsLogged = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sets_logged_total",
+		Help:      "Performed sets logged in workout sessions.",
+	})
+
+	RecordsSet = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "records_set_total",
+		Help:      "Personal records set, by record type.",
+	}, []string{"type"})
+
+	ProgressionAdjustments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "progression_adjustments_total",
+		Help:      "Progression rules evaluated on finished sessions, by outcome.",
+	}, []string{"outcome"})
+
+	TrashPurged = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "trash_purged_total",
+		Help:      "Soft-deleted rows permanently deleted by the retention job, by table.",
+	}, []string{"table"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		HTTPRequests,
+		HTTPDuration,
+		DBQueryDuration,
+		RateLimitRejections,
+		EmailsSent,
+		WorkoutsCreated,
+		SchedulesCreated,
+		SchedulesCompleted,
+		ProgramEnrollments,
+		SessionsFinished,
+		SetsLogged,
+		RecordsSet,
+		ProgressionAdjustments,
+		TrashPurged,
+	)
+}
+
+// EmailResult records the outcome of sending an email.
+func EmailResult(err error) {
+	if err != nil {
+		EmailsSent.WithLabelValues("failure").Inc()
+		return
+	}
+	EmailsSent.WithLabelValues("success").Inc()
+}
+
+const startKey = "metrics:start"
+
+// InstrumentDB times every GORM query through callbacks and exports the
+// connection pool statistics of db.
+func InstrumentDB(db *gorm.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db.DB(), "kinetic_core"))
+
+	callbacks := db.Callback()
+	callbacks.Create().Before("gorm:create").Register("metrics:before_create", start)
+	callbacks.Create().After("gorm:create").Register("metrics:after_create", observe("create"))
+	callbacks.Query().Before("gorm:query").Register("metrics:before_query", start)
+	callbacks.Query().After("gorm:query").Register("metrics:after_query", observe("query"))
+	callbacks.Update().Before("gorm:update").Register("metrics:before_update", start)
+	callbacks.Update().After("gorm:update").Register("metrics:after_update", observe("update"))
+	callbacks.Delete().Before("gorm:delete").Register("metrics:before_delete", start)
+	callbacks.Delete().After("gorm:delete").Register("metrics:after_delete", observe("delete"))
+	callbacks.RowQuery().Before("gorm:row_query").Register("metrics:before_row_query", start)
+	callbacks.RowQuery().After("gorm:row_query").Register("metrics:after_row_query", observe("row_query"))
+}
+
+func start(scope *gorm.Scope) {
+	scope.Set(startKey, time.Now())
+}
+
+func observe(operation string) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		value, ok := scope.Get(startKey)
+		if !ok {
+			return
+		}
+		DBQueryDuration.WithLabelValues(operation, scope.TableName()).Observe(time.Since(value.(time.Time)).Seconds())
+	}
+}
@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/tracing"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyLease is how long a request may stay in flight. A record still
+// without a response after it belongs to a request that was lost, say to a
+// crash, and a retry takes its key over.
+const idempotencyLease = 2 * time.Minute
+
+// idempotencyRecord is the stored outcome of a request made with an
+// Idempotency-Key. StatusCode is 0 while the first request is in flight.
+type idempotencyRecord struct {
+	ID             uint `gorm:"primary_key"`
+	UserId         int64
+	IdempotencyKey string
+	Fingerprint    string
+	StatusCode     int
+	ContentType    string
+	Body           string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
+
+func (idempotencyRecord) TableName() string {
+	return "idempotency_keys"
+}
+
+// Idempotency makes a POST safe to retry. The first request with a given
+// Idempotency-Key header has its response stored per user for ttl; a retry
+// with the same key, query and body gets the stored response replayed,
+// marked with an Idempotent-Replayed header, instead of running the handler
+// again.
+//
+// Reusing a key with a different query or body, or while the first request
+// is still running, fails with 409; a first request that never finished is
+// given up on after idempotencyLease. Server errors are not stored, so a
+// retry after one runs the handler again. Requests without the header, or
+// without a valid token, are passed through unchanged.
+func Idempotency(db *gorm.DB, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			apperror.Abort(c, apperror.BadRequest("invalid_idempotency_key", "Idempotency-Key must be at most 255 characters"))
+			return
+		}
+		userId, err := utils.ExtractUserIdFromJWTToken(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			apperror.Abort(c, apperror.BadRequest("invalid_request_body", "Invalid request body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(c.Request, body)
+
+		tx := db.Set(tracing.DBContextKey, c.Request.Context())
+		now := time.Now()
+		if err := tx.Where("user_id = ? AND idempotency_key = ?", userId, key).
+			Where("expires_at < ? OR (status_code = 0 AND created_at < ?)", now, now.Add(-idempotencyLease)).
+			Delete(&idempotencyRecord{}).Error; err != nil {
+			apperror.Abort(c, apperror.Database(err, "Failed to check idempotency key"))
+			return
+		}
+
+		record := idempotencyRecord{
+			UserId:         userId,
+			IdempotencyKey: key,
+			Fingerprint:    fingerprint,
+			ExpiresAt:      now.Add(ttl),
+		}
+		if err := tx.Create(&record).Error; err != nil {
+			// The key is taken: replay the outcome of the first request.
+			var existing idempotencyRecord
+			if lookupErr := tx.Where("user_id = ? AND idempotency_key = ?", userId, key).First(&existing).Error; lookupErr != nil {
+				apperror.Abort(c, apperror.Database(err, "Failed to store idempotency key"))
+				return
+			}
+			switch {
+			case existing.Fingerprint != fingerprint:
+				apperror.Abort(c, apperror.Conflict("idempotency_key_reused", "Idempotency-Key was already used for a different request"))
+			case existing.StatusCode == 0:
+				apperror.Abort(c, apperror.Conflict("idempotency_request_in_progress", "A request with this Idempotency-Key is still being processed"))
+			default:
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(existing.StatusCode, existing.ContentType, []byte(existing.Body))
+				c.Abort()
+			}
+			return
+		}
+
+		// Store the outcome even if the client went away, so its retry is
+		// answered from the record. A panicking handler is treated as a
+		// server error.
+		tx = db.Set(tracing.DBContextKey, context.WithoutCancel(c.Request.Context()))
+		completed := false
+		defer func() {
+			if !completed {
+				tx.Delete(&record)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		completed = true
+
+		if status := recorder.Status(); status >= http.StatusInternalServerError {
+			err = tx.Delete(&record).Error
+		} else {
+			err = tx.Model(&record).Updates(map[string]interface{}{
+				"status_code":  status,
+				"content_type": recorder.Header().Get("Content-Type"),
+				"body":         recorder.body.String(),
+			}).Error
+		}
+		if err != nil {
+			// Free the key rather than leave it in flight for every retry.
+			slog.ErrorContext(c.Request.Context(), "Failed to store idempotent response", "error", err)
+			if err := tx.Delete(&record).Error; err != nil {
+				slog.ErrorContext(c.Request.Context(), "Failed to release idempotency key", "error", err)
+			}
+		}
+	}
+}
+
+// PurgeExpiredIdempotencyKeys deletes the records of keys whose ttl ended
+// before now and returns how many there were. Expired keys are otherwise only
+// deleted when their user reuses them.
+func PurgeExpiredIdempotencyKeys(db *gorm.DB, now time.Time) (int64, error) {
+	result := db.Where("expires_at < ?", now).Delete(&idempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
+
+// requestFingerprint identifies a request by its method, path, query and
+// body. The query is part of it as it can change how the body is read, as
+// unit does for loads and distances; its parameters are sorted so that their
+// order does not matter.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.Query().Encode() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body written through it.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
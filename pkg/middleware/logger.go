@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/url"
+	"time"
+	"workout_tracker/pkg/logging"
+	"workout_tracker/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Logger writes one structured line per request, skipping the given paths.
+// When the request carries a valid token the user id is added to the request
+// context, so handler logs include it as well. Sensitive query parameters
+// are redacted.
+func Logger(skipPaths ...string) gin.HandlerFunc {
+	skip := map[string]bool{}
+	for _, path := range skipPaths {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if skip[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		if c.GetHeader("Authorization") != "" {
+			if userId, err := utils.ExtractUserIdFromJWTToken(c.Request); err == nil {
+				c.Request = c.Request.WithContext(logging.WithUserID(c.Request.Context(), userId))
+			}
+		}
+
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if query := redactQuery(c.Request.URL.Query()); query != "" {
+			attrs = append(attrs, slog.String("query", query))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		slog.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+func redactQuery(values url.Values) string {
+	for key := range values {
+		if logging.IsSensitive(key) {
+			values.Set(key, logging.Redacted)
+		}
+	}
+	query, err := url.QueryUnescape(values.Encode())
+	if err != nil {
+		return values.Encode()
+	}
+	return query
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+	"workout_tracker/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics records the count and latency of every request, labeled by route
+// template rather than raw path to keep label cardinality bounded.
+func Metrics(skipPaths ...string) gin.HandlerFunc {
+	skip := map[string]bool{}
+	for _, path := range skipPaths {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if skip[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HTTPDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
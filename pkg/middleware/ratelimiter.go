@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"net/http"
+	"workout_tracker/pkg/apperror"
+	"workout_tracker/pkg/metrics"
 
 	"github.com/gin-gonic/gin"
 	"github.com/juju/ratelimit"
@@ -11,9 +13,8 @@ func NonBlockingRateLimitMiddleware(rateLimit *ratelimit.Bucket) gin.HandlerFunc
 	return func(c *gin.Context) {
 		// If there is no token available, deny the request immediately.
 		if rateLimit.TakeAvailable(1) == 0 {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error": "Too many requests. Please try again later.",
-			})
+			metrics.RateLimitRejections.Inc()
+			apperror.Abort(c, apperror.New(http.StatusTooManyRequests, "rate_limited", "Too many requests. Please try again later."))
 			return
 		}
 
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"workout_tracker/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery turns a panic into a 500 problem response, logging the panic
+// value and stack without exposing them to the client.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.ErrorContext(c.Request.Context(), "Panic recovered", "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+				if c.Writer.Written() {
+					c.Abort()
+					return
+				}
+				apperror.Abort(c, apperror.Internal("Internal server error", fmt.Errorf("panic: %v", r)))
+			}
+		}()
+		c.Next()
+	}
+}
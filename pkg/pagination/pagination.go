@@ -0,0 +1,176 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"workout_tracker/pkg/apperror"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Params are the query parameters shared by every list endpoint. sort names
+// one of the list's sortable fields, prefixed with "-" for descending order.
+type Params struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort"`
+}
+
+// Sortable maps the sort names a list accepts to their column.
+type Sortable map[string]string
+
+// Meta is returned alongside a page of results. NextCursor is null on the
+// last page.
+type Meta struct {
+	Limit      int     `json:"limit"`
+	Total      int     `json:"total"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// Page is a validated request for one page of a list, ordered by a sortable
+// column and then by id so that every row has a stable position.
+type Page struct {
+	limit  int
+	sort   string
+	column string
+	desc   bool
+	after  *cursor
+}
+
+// cursor marks the last row of the previous page. It is handed to clients
+// base64 encoded and must be treated as opaque.
+type cursor struct {
+	Sort  string      `json:"s"`
+	Value interface{} `json:"v"`
+	Time  *time.Time  `json:"t,omitempty"`
+	ID    uint        `json:"id"`
+}
+
+// New validates params against the list's sortable fields. defaultSort is
+// used when no sort is given. A cursor is only valid with the sort it was
+// issued for.
+func New(params Params, sortable Sortable, defaultSort string) (Page, *apperror.Error) {
+	page := Page{limit: params.Limit, sort: params.Sort}
+	if page.limit == 0 {
+		page.limit = DefaultLimit
+	}
+	if page.sort == "" {
+		page.sort = defaultSort
+	}
+
+	name := strings.TrimPrefix(page.sort, "-")
+	column, ok := sortable[name]
+	if !ok {
+		return Page{}, apperror.Validation(apperror.FieldError{
+			Field:   "sort",
+			Code:    "oneof",
+			Message: "must be one of: " + strings.Join(sortNames(sortable), ", ") + ", optionally prefixed with -",
+		})
+	}
+	page.column = column
+	page.desc = strings.HasPrefix(page.sort, "-")
+
+	if params.Cursor != "" {
+		after, err := decodeCursor(params.Cursor)
+		if err != nil || after.Sort != page.sort {
+			return Page{}, apperror.Validation(apperror.FieldError{
+				Field:   "cursor",
+				Code:    "invalid",
+				Message: "is not a cursor for this list and sort",
+			})
+		}
+		page.after = after
+	}
+	return page, nil
+}
+
+func sortNames(sortable Sortable) []string {
+	names := make([]string, 0, len(sortable))
+	for name := range sortable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Find loads the page from db, which should already carry the list's
+// filters, and counts the rows matching those filters.
+func Find[T any](db *gorm.DB, page Page) ([]T, Meta, error) {
+	meta := Meta{Limit: page.limit}
+	if err := db.Model(new(T)).Count(&meta.Total).Error; err != nil {
+		return nil, meta, err
+	}
+
+	dir, cmp := "ASC", ">"
+	if page.desc {
+		dir, cmp = "DESC", "<"
+	}
+	column := db.Dialect().Quote(page.column)
+	query := db.Order(column + " " + dir).Order("id " + dir).Limit(page.limit + 1)
+	if page.after != nil {
+		value := page.after.Value
+		if page.after.Time != nil {
+			value = *page.after.Time
+		}
+		query = query.Where(column+" "+cmp+" ? OR ("+column+" = ? AND id "+cmp+" ?)", value, value, page.after.ID)
+	}
+
+	rows := make([]T, 0, page.limit+1)
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, meta, err
+	}
+	if len(rows) > page.limit {
+		rows = rows[:page.limit]
+		next, err := page.cursorAfter(db, &rows[len(rows)-1])
+		if err != nil {
+			return nil, meta, err
+		}
+		meta.NextCursor = &next
+	}
+	return rows, meta, nil
+}
+
+func (p Page) cursorAfter(db *gorm.DB, row interface{}) (string, error) {
+	scope := db.NewScope(row)
+	field, ok := scope.FieldByName(p.column)
+	if !ok {
+		return "", errors.New("pagination: no field for column " + p.column)
+	}
+	id, ok := scope.FieldByName("id")
+	if !ok {
+		return "", errors.New("pagination: no id field")
+	}
+
+	next := cursor{Sort: p.sort, ID: uint(id.Field.Uint())}
+	if t, ok := field.Field.Interface().(time.Time); ok {
+		next.Time = &t
+	} else {
+		next.Value = field.Field.Interface()
+	}
+	data, err := json.Marshal(next)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -13,13 +13,13 @@ var categories = []model.ExerciseCategory{
 }
 
 var exercises = []model.Exercise{
-	{Name: "Push-up", Description: "A classic bodyweight exercise for the chest, shoulders, and triceps.", Category: 1, MuscleGroup: "Chest"},
-	{Name: "Squat", Description: "A fundamental lower body exercise that targets the quads, hamstrings, and glutes.", Category: 1, MuscleGroup: "Legs"},
-	{Name: "Plank", Description: "An isometric core strength exercise that involves maintaining a position similar to a push-up for the maximum possible time.", Category: 1, MuscleGroup: "Core"},
-	{Name: "Running", Description: "A popular form of cardiovascular exercise.", Category: 2, MuscleGroup: "Full Body"},
-	{Name: "Hamstring Stretch", Description: "A stretch to improve flexibility in the back of the thigh.", Category: 2, MuscleGroup: "Legs"},
-	{Name: "Bicep Curl", Description: "A weight training exercise that targets the biceps.", Category: 1, MuscleGroup: "Arms"},
-	{Name: "Pull-up", Description: "An upper-body strength exercise where the body is pulled up.", Category: 1, MuscleGroup: "Back"},
+	{Name: "Push-up", Description: "A classic bodyweight exercise for the chest, shoulders, and triceps.", Category: 1, MuscleGroup: "Chest", Measurement: model.MeasureReps},
+	{Name: "Squat", Description: "A fundamental lower body exercise that targets the quads, hamstrings, and glutes.", Category: 1, MuscleGroup: "Legs", Measurement: model.MeasureRepsLoad},
+	{Name: "Plank", Description: "An isometric core strength exercise that involves maintaining a position similar to a push-up for the maximum possible time.", Category: 1, MuscleGroup: "Core", Measurement: model.MeasureDuration},
+	{Name: "Running", Description: "A popular form of cardiovascular exercise.", Category: 2, MuscleGroup: "Full Body", Measurement: model.MeasureDistanceDuration},
+	{Name: "Hamstring Stretch", Description: "A stretch to improve flexibility in the back of the thigh.", Category: 2, MuscleGroup: "Legs", Measurement: model.MeasureDuration},
+	{Name: "Bicep Curl", Description: "A weight training exercise that targets the biceps.", Category: 1, MuscleGroup: "Arms", Measurement: model.MeasureRepsLoad},
+	{Name: "Pull-up", Description: "An upper-body strength exercise where the body is pulled up.", Category: 1, MuscleGroup: "Back", Measurement: model.MeasureReps},
 }
 
 func main() {
@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ServiceName = "kinetic-core"
+	// DBContextKey is the GORM scope setting holding the request context, as
+	// GORM v1 does not carry one itself.
+	DBContextKey = "tracing:context"
+	spanKey      = "tracing:span"
+)
+
+// Tracer is used for the application's own spans.
+var Tracer = otel.Tracer("workout_tracker")
+
+// system is the db.system attribute recorded on query spans.
+var system string
+
+// Setup installs the global tracer provider and W3C trace-context
+// propagation. OTEL_TRACES_EXPORTER selects "otlp" (configured through the
+// standard OTEL_EXPORTER_OTLP_* variables), "stdout" for development, or
+// "none", the default. The returned function flushes pending spans.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch name := strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")); name {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// InstrumentDB starts a child span for every GORM query made through a
+// handle carrying the request context (see config.GetDBContext).
+func InstrumentDB(db *gorm.DB, dialect string) {
+	callbacks := db.Callback()
+	callbacks.Create().Before("gorm:create").Register("tracing:before_create", start("create"))
+	callbacks.Create().After("gorm:create").Register("tracing:after_create", end)
+	callbacks.Query().Before("gorm:query").Register("tracing:before_query", start("query"))
+	callbacks.Query().After("gorm:query").Register("tracing:after_query", end)
+	callbacks.Update().Before("gorm:update").Register("tracing:before_update", start("update"))
+	callbacks.Update().After("gorm:update").Register("tracing:after_update", end)
+	callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", start("delete"))
+	callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", end)
+	callbacks.RowQuery().Before("gorm:row_query").Register("tracing:before_row_query", start("row_query"))
+	callbacks.RowQuery().After("gorm:row_query").Register("tracing:after_row_query", end)
+
+	system = dialect
+}
+
+func start(operation string) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		value, ok := scope.Get(DBContextKey)
+		if !ok {
+			return
+		}
+		ctx, ok := value.(context.Context)
+		if !ok || !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			return
+		}
+		_, span := Tracer.Start(ctx, "gorm."+operation+" "+scope.TableName(),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemKey.String(system),
+				semconv.DBOperationName(operation),
+				semconv.DBCollectionName(scope.TableName()),
+			))
+		scope.Set(spanKey, span)
+	}
+}
+
+func end(scope *gorm.Scope) {
+	value, ok := scope.Get(spanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	span.SetAttributes(
+		semconv.DBQueryText(scope.SQL),
+		attribute.Int64("db.rows_affected", scope.DB().RowsAffected),
+	)
+	if err := scope.DB().Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
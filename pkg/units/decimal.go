@@ -0,0 +1,80 @@
+package units
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Decimal is a quantity with up to three decimal places, such as a load in
+// kilograms or a distance in meters. It is held as an integer number of
+// thousandths so that values round-trip exactly through DECIMAL columns and
+// JSON.
+type Decimal int64
+
+// NewDecimal rounds f to the nearest thousandth.
+func NewDecimal(f float64) Decimal {
+	return Decimal(math.Round(f * 1000))
+}
+
+// Float64 returns d as a float.
+func (d Decimal) Float64() float64 {
+	return float64(d) / 1000
+}
+
+// String formats d without trailing zeros, as in 102.5 or 80.
+func (d Decimal) String() string {
+	sign := ""
+	n := int64(d)
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	s := fmt.Sprintf("%s%d.%03d", sign, n/1000, n%1000)
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		// Reported like any other JSON value of the wrong type.
+		return &json.UnmarshalTypeError{Value: "value " + string(data), Type: reflect.TypeOf(f)}
+	}
+	*d = NewDecimal(f)
+	return nil
+}
+
+// Value stores d as its decimal text, which DECIMAL columns take exactly.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan reads a DECIMAL, which drivers return as text, or a number from
+// databases without a decimal type.
+func (d *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = 0
+	case float64:
+		*d = NewDecimal(v)
+	case int64:
+		*d = Decimal(v * 1000)
+	case []byte:
+		return d.UnmarshalJSON(v)
+	case string:
+		return d.UnmarshalJSON([]byte(v))
+	default:
+		return fmt.Errorf("units: cannot scan %T into Decimal", src)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+// Package units converts loads and distances between the SI units they are
+// stored in and the unit system a user works in.
+//
+// Struct fields holding a quantity are tagged with its kind, as in
+// `unit:"load"`. ToSI and FromSI convert every tagged field reachable from a
+// value, through nested structs, pointers and slices, so request and
+// response bodies can be converted in a single call.
+package units
+
+import (
+	"math"
+	"reflect"
+)
+
+// System is a unit system.
+type System string
+
+const (
+	Metric   System = "metric"
+	Imperial System = "imperial"
+)
+
+// Quantity kinds, as used in `unit` struct tags.
+const (
+	Load      = "load"      // kilograms or pounds
+	Distance  = "distance"  // meters or miles
+	Elevation = "elevation" // meters or feet
+	Pace      = "pace"      // seconds per kilometer or per mile
+)
+
+type conversion struct {
+	// si is the size of the imperial unit in SI units.
+	si float64
+	// places is the precision imperial values are reported with.
+	places int
+	symbol string
+}
+
+var imperial = map[string]conversion{
+	Load:      {si: 0.45359237, places: 2, symbol: "lb"},
+	Distance:  {si: 1609.344, places: 3, symbol: "mi"},
+	Elevation: {si: 0.3048, places: 1, symbol: "ft"},
+	Pace:      {si: 1 / 1.609344, places: 0, symbol: "s/mi"},
+}
+
+var metricSymbols = map[string]string{
+	Load:      "kg",
+	Distance:  "m",
+	Elevation: "m",
+	Pace:      "s/km",
+}
+
+// Valid reports whether s is a known unit system.
+func (s System) Valid() bool {
+	return s == Metric || s == Imperial
+}
+
+// ToSI converts the tagged fields reachable from ptr from s to SI units, in
+// place.
+func ToSI(s System, ptr interface{}) {
+	if s != Imperial {
+		return
+	}
+	walk(reflect.ValueOf(ptr), toSI)
+}
+
+// FromSI converts the tagged fields reachable from ptr from SI units to s,
+// in place, rounding them to a precision that suits the unit.
+func FromSI(s System, ptr interface{}) {
+	if s != Imperial {
+		return
+	}
+	walk(reflect.ValueOf(ptr), fromSI)
+}
+
+// MergeSI converts the tagged fields reachable from edited from s to SI
+// units, as ToSI does, except the fields an edit left as they were shown.
+// shown is the resource as FromSI gave it to the client and stored the same
+// resource in SI units; a tagged field of edited equal to its counterpart in
+// shown takes its value from stored instead, so that fields a patch does not
+// touch keep their stored value exactly rather than going through the
+// rounding of FromSI and back. edited, shown and stored point to values of
+// the same type. Elements of slices are matched by index.
+func MergeSI(s System, edited, shown, stored interface{}) {
+	if s != Imperial {
+		return
+	}
+	merge(reflect.ValueOf(edited), reflect.ValueOf(shown), reflect.ValueOf(stored))
+}
+
+func toSI(kind string, f float64) float64 {
+	return f * imperial[kind].si
+}
+
+// merge walks edited along with its counterparts in shown and stored, which
+// are invalid where they have none.
+func merge(edited, shown, stored reflect.Value) {
+	if !shown.IsValid() || !stored.IsValid() || shown.Kind() != edited.Kind() {
+		walk(edited, toSI)
+		return
+	}
+	switch edited.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if edited.IsNil() {
+			return
+		}
+		if shown.IsNil() || stored.IsNil() {
+			walk(edited, toSI)
+			return
+		}
+		merge(edited.Elem(), shown.Elem(), stored.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < edited.Len(); i++ {
+			if i < shown.Len() && i < stored.Len() {
+				merge(edited.Index(i), shown.Index(i), stored.Index(i))
+			} else {
+				walk(edited.Index(i), toSI)
+			}
+		}
+	case reflect.Struct:
+		t := edited.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := edited.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			kind, ok := t.Field(i).Tag.Lookup("unit")
+			if !ok {
+				merge(field, shown.Field(i), stored.Field(i))
+				continue
+			}
+			if _, known := imperial[kind]; !known {
+				continue
+			}
+			if reflect.DeepEqual(field.Interface(), shown.Field(i).Interface()) {
+				field.Set(stored.Field(i))
+			} else {
+				convertField(field, kind, toSI)
+			}
+		}
+	}
+}
+
+// Convert returns d, a quantity of the given kind in SI units, in the units
+// of s, rounded as FromSI does.
+func Convert(s System, kind string, d Decimal) Decimal {
+	if s != Imperial {
+		return d
+	}
+	return NewDecimal(fromSI(kind, d.Float64()))
+}
+
+// Symbol returns the symbol of the unit quantities of the given kind are in
+// with s, as in kg or lb.
+func Symbol(s System, kind string) string {
+	if s == Imperial {
+		return imperial[kind].symbol
+	}
+	return metricSymbols[kind]
+}
+
+func fromSI(kind string, f float64) float64 {
+	conv := imperial[kind]
+	scale := math.Pow(10, float64(conv.places))
+	return math.Round(f/conv.si*scale) / scale
+}
+
+var decimalType = reflect.TypeOf(Decimal(0))
+
+func walk(v reflect.Value, convert func(kind string, f float64) float64) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem(), convert)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), convert)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if kind, ok := t.Field(i).Tag.Lookup("unit"); ok {
+				if _, known := imperial[kind]; known {
+					convertField(field, kind, convert)
+				}
+				continue
+			}
+			walk(field, convert)
+		}
+	}
+}
+
+func convertField(v reflect.Value, kind string, convert func(kind string, f float64) float64) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Type() == decimalType:
+		d := v.Interface().(Decimal)
+		v.Set(reflect.ValueOf(NewDecimal(convert(kind, d.Float64()))))
+	case v.CanFloat():
+		v.SetFloat(convert(kind, v.Float()))
+	case v.CanInt():
+		v.SetInt(int64(math.Round(convert(kind, float64(v.Int())))))
+	}
+}
+
+// Round rounds d, a quantity of the given kind in SI units, to a multiple of
+// step in s, as loads are rounded to the plates at hand.
+func Round(s System, kind string, d Decimal, step float64) Decimal {
+	size := 1.0
+	if s == Imperial {
+		size = imperial[kind].si
+	}
+	return NewDecimal(math.Round(d.Float64()/size/step) * step * size)
+}
@@ -0,0 +1,94 @@
+package units
+
+import "testing"
+
+type set struct {
+	Weight *Decimal `unit:"load"`
+	Reps   int64
+}
+
+type exercise struct {
+	Weight   Decimal `unit:"load"`
+	Distance Decimal `unit:"distance"`
+	Pace     int64   `unit:"pace"`
+	Notes    string
+	Sets     []set
+}
+
+func stored() exercise {
+	heavy, light := NewDecimal(100), NewDecimal(61.235)
+	return exercise{
+		Weight:   NewDecimal(100),
+		Distance: NewDecimal(5000),
+		Pace:     300,
+		Sets:     []set{{Weight: &light, Reps: 5}, {Weight: &heavy, Reps: 5}},
+	}
+}
+
+func TestFromSIToSIRounds(t *testing.T) {
+	value := stored()
+	FromSI(Imperial, &value)
+	ToSI(Imperial, &value)
+	if value.Weight == NewDecimal(100) {
+		t.Fatalf("expected a plain round trip through pounds to round, got %s kg", value.Weight)
+	}
+}
+
+func TestMergeSIKeepsUnchangedFields(t *testing.T) {
+	original, shown := stored(), stored()
+	FromSI(Imperial, &shown)
+	if shown.Weight != NewDecimal(220.46) {
+		t.Fatalf("shown weight = %s lb, want 220.46", shown.Weight)
+	}
+
+	edited := stored()
+	FromSI(Imperial, &edited)
+	edited.Notes = "only the notes changed"
+	MergeSI(Imperial, &edited, &shown, &original)
+
+	if edited.Weight != original.Weight {
+		t.Errorf("weight = %s kg, want %s", edited.Weight, original.Weight)
+	}
+	if edited.Distance != original.Distance {
+		t.Errorf("distance = %s m, want %s", edited.Distance, original.Distance)
+	}
+	if edited.Pace != original.Pace {
+		t.Errorf("pace = %d s/km, want %d", edited.Pace, original.Pace)
+	}
+	for i := range edited.Sets {
+		if *edited.Sets[i].Weight != *original.Sets[i].Weight {
+			t.Errorf("sets[%d].weight = %s kg, want %s", i, *edited.Sets[i].Weight, *original.Sets[i].Weight)
+		}
+	}
+}
+
+func TestMergeSIConvertsChangedFields(t *testing.T) {
+	original, shown := stored(), stored()
+	FromSI(Imperial, &shown)
+
+	edited := stored()
+	FromSI(Imperial, &edited)
+	edited.Weight = NewDecimal(225)
+	added := NewDecimal(135)
+	edited.Sets = append(edited.Sets, set{Weight: &added, Reps: 8})
+	MergeSI(Imperial, &edited, &shown, &original)
+
+	if want := NewDecimal(225 * 0.45359237); edited.Weight != want {
+		t.Errorf("weight = %s kg, want %s", edited.Weight, want)
+	}
+	if *edited.Sets[1].Weight != NewDecimal(100) {
+		t.Errorf("sets[1].weight = %s kg, want 100", *edited.Sets[1].Weight)
+	}
+	if want := NewDecimal(135 * 0.45359237); *edited.Sets[2].Weight != want {
+		t.Errorf("sets[2].weight = %s kg, want %s", *edited.Sets[2].Weight, want)
+	}
+}
+
+func TestMergeSIMetricLeavesValues(t *testing.T) {
+	original, shown, edited := stored(), stored(), stored()
+	edited.Weight = NewDecimal(102.5)
+	MergeSI(Metric, &edited, &shown, &original)
+	if edited.Weight != NewDecimal(102.5) {
+		t.Errorf("weight = %s kg, want 102.5", edited.Weight)
+	}
+}
@@ -1,32 +1,49 @@
 package utils
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/smtp"
 	"os"
+	"workout_tracker/pkg/metrics"
+	"workout_tracker/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func SendEmail(recipient, name, subject, message string) error {
+func SendEmail(ctx context.Context, recipient, name, subject, message string) error {
+	ctx, span := tracing.Tracer.Start(ctx, "SendEmail", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("email.subject", subject)))
+	defer span.End()
+
 	host := os.Getenv("SMTP_HOST")
 	port := os.Getenv("SMTP_PORT")
 	user := os.Getenv("SMTP_USER")
 	password := os.Getenv("SMTP_PASSWORD")
 	if host == "" || user == "" || password == "" {
 		err := fmt.Errorf("SMTP environment variables are not set")
-		log.Println(err)
+		slog.ErrorContext(ctx, "Failed to send email", "error", err)
+		metrics.EmailResult(err)
+		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 
 	addr := fmt.Sprintf("%s:%s", host, port)
+	span.SetAttributes(attribute.String("server.address", host), attribute.String("server.port", port))
 	auth := smtp.PlainAuth("", user, password, host)
 	to := []string{recipient}
 	msg := []byte("To: " + recipient + "\r\n" + message + "\r\n")
 
 	err := smtp.SendMail(addr, auth, user, to, msg)
+	metrics.EmailResult(err)
 	if err != nil {
-		log.Printf("SMTP Error: %v", err)
+		slog.ErrorContext(ctx, "SMTP error", "host", host, "error", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}